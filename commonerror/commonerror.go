@@ -1,7 +1,9 @@
 package commonerror
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -11,19 +13,53 @@ type ICommonError interface {
 	error
 	Code() int32
 	Msg() string
+	GRPCCode() codes.Code
+	HTTPStatus() int
+	Details() *Details
+	Retryability() Retryability
+	RetryDelay() time.Duration
+	GRPCStatus() *status.Status
 }
 
 // CommonError - standardizes error reporting between grpc services
 type CommonError struct {
-	msg  string
-	code int32
+	cause   error
+	details *Details
+	msg     string
+	code    int32
 }
 
-// Error - returns a formatted string describing common error code and message
+// Error - returns a formatted string describing common error code and message,
+// followed by the wrapped cause if any.
 func (ce *CommonError) Error() string {
+	if ce.cause != nil {
+		return fmt.Sprintf(WrapFormat, fmt.Sprintf(ErrorFormat, ce.code, ce.msg), ce.cause.Error())
+	}
+
 	return fmt.Sprintf(ErrorFormat, ce.code, ce.msg)
 }
 
+// Unwrap - returns the wrapped cause, nil if there is none
+func (ce *CommonError) Unwrap() error {
+	return ce.cause
+}
+
+// Is - checks if target is a common error of the same code, so that
+// errors.Is(err, commonerror.New(code, "")) matches any common error of code
+// within err's chain.
+//
+// Common errors are equal by code alone, their msgs, details and causes are
+// not compared. Sentinels meant to be told apart by errors.Is must therefore
+// use distinct codes, registered through Register if not builtin.
+func (ce *CommonError) Is(target error) bool {
+	targetErr, ok := target.(*CommonError)
+	if !ok {
+		return false
+	}
+
+	return targetErr.code == ce.code
+}
+
 // Code - returns common error code
 func (ce *CommonError) Code() int32 {
 	return ce.code
@@ -34,50 +70,95 @@ func (ce *CommonError) Msg() string {
 	return ce.msg
 }
 
+// GRPCCode - returns grpc code mapped from common error code
+func (ce *CommonError) GRPCCode() codes.Code {
+	return CommonErrCodeToGRPC(ce.code)
+}
+
+// Details - returns structured details of common error, nil if there are none
+func (ce *CommonError) Details() *Details {
+	return ce.details
+}
+
+// GRPCStatus - returns grpc status with common error code and details encoded
+// into its details.
+//
+// grpc uses this method to build the status sent over the wire when a handler
+// returns a common error.
+func (ce *CommonError) GRPCStatus() *status.Status {
+	grpcStatus := status.New(ce.GRPCCode(), ce.msg)
+
+	grpcStatusWithDetails, err := grpcStatus.WithDetails(encodeDetails(ce.code, ce.details)...)
+	if err != nil {
+		return grpcStatus
+	}
+
+	return grpcStatusWithDetails
+}
+
 // New - initializes a new common error
 func New(code int32, msg string) ICommonError {
+	return NewWithDetails(code, msg, nil)
+}
+
+// Wrap - initializes a new common error wrapping cause, preserving its chain
+// for errors.Is and errors.As
+func Wrap(code int32, msg string, cause error) ICommonError {
+	return newCommonError(code, msg, nil, cause)
+}
+
+// NewWithDetails - initializes a new common error with structured details
+func NewWithDetails(code int32, msg string, details *Details) ICommonError {
+	return newCommonError(code, msg, details, nil)
+}
+
+// newCommonError - initializes a new common error, populating retry info for
+// codes registered as retryable.
+func newCommonError(code int32, msg string, details *Details, cause error) ICommonError {
 	if code == CodeOk {
 		return nil
 	}
 
 	return &CommonError{
-		code: code,
-		msg:  msg,
+		code:    code,
+		msg:     msg,
+		details: withDefaultRetryInfo(code, details),
+		cause:   cause,
 	}
 }
 
-// Convert - converts inbuilt error to common error
+// Convert - converts inbuilt error to common error.
+//
+// The outermost common error within err's chain is returned as is. Otherwise,
+// common error code and details encoded by GRPCStatus are decoded back,
+// or the common error code is derived from the grpc code.
 func Convert(err error) ICommonError {
 	if err == nil {
 		return &CommonError{}
 	}
 
-	if ce, ok := err.(*CommonError); ok {
+	ce := &CommonError{}
+	if errors.As(err, &ce) {
 		return ce
 	}
 
+	var grpcStatusErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcStatusErr) {
+		err = grpcStatusErr.GRPCStatus().Err()
+	}
+
 	grpcStatus := status.Convert(err)
 	code, msg := grpcStatus.Code(), grpcStatus.Message()
-	errCode := grpcToCommonErrCode(code)
 
-	return &CommonError{
-		code: errCode,
-		msg:  msg,
+	errCode, details, ok := decodeDetails(grpcStatus.Details())
+	if !ok {
+		errCode = GRPCToCommonErrCode(code)
+		details = withDefaultRetryInfo(errCode, details)
 	}
-}
 
-func grpcToCommonErrCode(code codes.Code) int32 {
-	commonErrCode := int32(code)
-
-	switch code {
-	case codes.Unknown:
-		commonErrCode = ErrCodeUnknown
-	case codes.Internal:
-		commonErrCode = ErrCodeServer
-	case codes.DeadlineExceeded:
-		commonErrCode = ErrCodeTimeout
-	default:
+	return &CommonError{
+		code:    errCode,
+		msg:     msg,
+		details: details,
 	}
-
-	return commonErrCode
 }
@@ -1,9 +1,15 @@
 package commonerror
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewCode0Nil(t *testing.T) {
@@ -28,3 +34,87 @@ func TestConvertSameCodeAndMsg(t *testing.T) {
 	assert.Equal(t, commonError.Msg(), commonErrorConvert.Msg())
 	assert.Equal(t, commonError.Error(), commonErrorConvert.Error())
 }
+
+func TestConvertGRPCStatusSameCodeAndMsg(t *testing.T) {
+	commonError := New(ErrCodeTimeout, ErrMsgTimeout)
+	commonErrorConvert := Convert(commonError.GRPCStatus().Err())
+
+	assert.Equal(t, commonError.Code(), commonErrorConvert.Code())
+	assert.Equal(t, commonError.Msg(), commonErrorConvert.Msg())
+	assert.Equal(t, &Details{RetryInfo: &RetryInfo{}}, commonErrorConvert.Details())
+	assert.Equal(t, Retryable, commonErrorConvert.Retryability())
+}
+
+func TestConvertGRPCStatusNonRetryableNoDetails(t *testing.T) {
+	commonError := New(ErrCodeServer, ErrMsgServer)
+	commonErrorConvert := Convert(commonError.GRPCStatus().Err())
+
+	assert.Equal(t, commonError.Code(), commonErrorConvert.Code())
+	assert.Nil(t, commonErrorConvert.Details())
+}
+
+func TestConvertGRPCStatusSameDetails(t *testing.T) {
+	details := &Details{
+		Metadata:  map[string]string{"orderId": "123"},
+		RetryInfo: &RetryInfo{RetryDelay: 2 * time.Second},
+		FieldViolations: []*FieldViolation{
+			{Field: "email", Description: "invalid format"},
+		},
+		ResourceInfos: []*ResourceInfo{
+			{ResourceType: "order", ResourceName: "123", Owner: "user1", Description: "not found"},
+		},
+	}
+	commonError := NewWithDetails(ErrCodeServer, ErrMsgServer, details)
+	commonErrorConvert := Convert(commonError.GRPCStatus().Err())
+
+	assert.Equal(t, commonError.Code(), commonErrorConvert.Code())
+	assert.Equal(t, commonError.Msg(), commonErrorConvert.Msg())
+	assert.Equal(t, details, commonErrorConvert.Details())
+}
+
+func TestConvertGRPCStatusWithoutCommonCode(t *testing.T) {
+	err := status.Error(codes.Internal, ErrMsgServer)
+	commonErrorConvert := Convert(err)
+
+	assert.Equal(t, int32(ErrCodeServer), commonErrorConvert.Code())
+	assert.Equal(t, ErrMsgServer, commonErrorConvert.Msg())
+}
+
+func TestConvertWrappedCommonError(t *testing.T) {
+	commonError := New(ErrCodeNotFound, ErrMsgNotFound)
+	err := fmt.Errorf("fail to get order: %w", commonError)
+	commonErrorConvert := Convert(err)
+
+	assert.Equal(t, commonError, commonErrorConvert)
+}
+
+func TestWrapPreservesCause(t *testing.T) {
+	commonError := Wrap(ErrCodeServer, ErrMsgServer, io.EOF)
+
+	assert.True(t, errors.Is(commonError, io.EOF))
+	assert.Equal(t, "common error: code=2, msg=server error | EOF", commonError.Error())
+}
+
+func TestErrorsIsMatchesCode(t *testing.T) {
+	err := fmt.Errorf("handler: %w", Wrap(ErrCodeNotFound, "order 123 not found", io.EOF))
+
+	assert.True(t, errors.Is(err, New(ErrCodeNotFound, "")))
+	assert.False(t, errors.Is(err, New(ErrCodeServer, "")))
+}
+
+func TestErrorsAsCommonError(t *testing.T) {
+	err := fmt.Errorf("handler: %w", New(ErrCodeNotFound, ErrMsgNotFound))
+
+	var commonError ICommonError
+
+	assert.True(t, errors.As(err, &commonError))
+	assert.Equal(t, int32(ErrCodeNotFound), commonError.Code())
+}
+
+func TestConvertWrappedGRPCStatus(t *testing.T) {
+	err := fmt.Errorf("call: %w", status.Error(codes.InvalidArgument, ErrMsgInvalidArgument))
+	commonErrorConvert := Convert(err)
+
+	assert.Equal(t, int32(ErrCodeInvalidArgument), commonErrorConvert.Code())
+	assert.Equal(t, ErrMsgInvalidArgument, commonErrorConvert.Msg())
+}
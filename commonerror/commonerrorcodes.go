@@ -2,14 +2,42 @@ package commonerror
 
 // Common Error Codes
 const (
-	ErrCodeGRPC    = 1
-	ErrCodeServer  = 2
-	ErrCodeUnknown = 3
-	ErrCodeTimeout = 4
+	ErrCodeGRPC               = 1
+	ErrCodeServer             = 2
+	ErrCodeUnknown            = 3
+	ErrCodeTimeout            = 4
+	ErrCodeCanceled           = 5
+	ErrCodeInvalidArgument    = 6
+	ErrCodeNotFound           = 7
+	ErrCodeAlreadyExists      = 8
+	ErrCodePermissionDenied   = 9
+	ErrCodeResourceExhausted  = 10
+	ErrCodeFailedPrecondition = 11
+	ErrCodeAborted            = 12
+	ErrCodeOutOfRange         = 13
+	ErrCodeUnimplemented      = 14
+	ErrCodeUnavailable        = 15
+	ErrCodeDataLoss           = 16
+	ErrCodeUnauthenticated    = 17
+	ErrCodeCircuitOpen        = 18
 )
 
 const (
-	ErrMsgServer  = "server error"
-	ErrMsgUnknown = "unknown error"
-	ErrMsgTimeout = "request timed out"
+	ErrMsgServer             = "server error"
+	ErrMsgUnknown            = "unknown error"
+	ErrMsgTimeout            = "request timed out"
+	ErrMsgCanceled           = "request canceled"
+	ErrMsgInvalidArgument    = "invalid argument"
+	ErrMsgNotFound           = "not found"
+	ErrMsgAlreadyExists      = "already exists"
+	ErrMsgPermissionDenied   = "permission denied"
+	ErrMsgResourceExhausted  = "resource exhausted"
+	ErrMsgFailedPrecondition = "failed precondition"
+	ErrMsgAborted            = "request aborted"
+	ErrMsgOutOfRange         = "out of range"
+	ErrMsgUnimplemented      = "unimplemented"
+	ErrMsgUnavailable        = "service unavailable"
+	ErrMsgDataLoss           = "data loss"
+	ErrMsgUnauthenticated    = "unauthenticated"
+	ErrMsgCircuitOpen        = "circuit breaker open"
 )
@@ -6,4 +6,31 @@ const (
 
 const (
 	ErrorFormat = "common error: code=%d, msg=%s"
+	WrapFormat  = "%s | %s"
+)
+
+const (
+	ErrorInfoDomain = "commonerror"
+)
+
+// Namespace and range of codes reserved for common error codes
+const (
+	CommonNamespace = "common"
+	ReservedCodeMin = 1
+	ReservedCodeMax = 999
+)
+
+// Localization
+const (
+	DefaultLocale             = "en"
+	LocaleMetadataKey         = "x-locale"
+	AcceptLanguageMetadataKey = "accept-language"
+)
+
+// HTTP
+const (
+	StatusClientClosedRequest = 499 // non-standard status for requests canceled by the client
+	ProblemContentType        = "application/problem+json"
+	ProblemTypeFormat         = "urn:problem-type:%s:%s" // namespace, name
+	ProblemTypeDefault        = "about:blank"
 )
@@ -0,0 +1,166 @@
+package commonerror
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Details - structured details carried by a common error across grpc services.
+type Details struct {
+	Metadata         map[string]string
+	RetryInfo        *RetryInfo
+	LocalizedMessage *LocalizedMessage
+	FieldViolations  []*FieldViolation
+	ResourceInfos    []*ResourceInfo
+}
+
+// FieldViolation - describes a single invalid field of a request.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// RetryInfo - describes when a client may retry a failed request.
+type RetryInfo struct {
+	RetryDelay time.Duration
+}
+
+// LocalizedMessage - user-facing msg localized for a locale.
+type LocalizedMessage struct {
+	Locale  string
+	Message string
+}
+
+// ResourceInfo - describes the resource that was being accessed.
+type ResourceInfo struct {
+	ResourceType string
+	ResourceName string
+	Owner        string
+	Description  string
+}
+
+// isEmpty - checks if details contain no information.
+func (d *Details) isEmpty() bool {
+	return d == nil ||
+		(len(d.Metadata) == 0 &&
+			d.RetryInfo == nil &&
+			d.LocalizedMessage == nil &&
+			len(d.FieldViolations) == 0 &&
+			len(d.ResourceInfos) == 0)
+}
+
+// encodeDetails - encodes common error code and details into google.rpc.Status details.
+//
+// The common error code is always encoded as an ErrorInfo under ErrorInfoDomain
+// so that it survives the lossy mapping to grpc codes.
+func encodeDetails(code int32, details *Details) []proto.Message {
+	errorInfo := &errdetails.ErrorInfo{
+		Reason: strconv.FormatInt(int64(code), 10),
+		Domain: ErrorInfoDomain,
+	}
+
+	if details == nil {
+		return []proto.Message{errorInfo}
+	}
+
+	errorInfo.Metadata = details.Metadata
+	messages := []proto.Message{errorInfo}
+
+	if details.RetryInfo != nil {
+		messages = append(messages, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(details.RetryInfo.RetryDelay),
+		})
+	}
+
+	if details.LocalizedMessage != nil {
+		messages = append(messages, &errdetails.LocalizedMessage{
+			Locale:  details.LocalizedMessage.Locale,
+			Message: details.LocalizedMessage.Message,
+		})
+	}
+
+	if len(details.FieldViolations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+
+		for _, violation := range details.FieldViolations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+
+		messages = append(messages, badRequest)
+	}
+
+	for _, resourceInfo := range details.ResourceInfos {
+		messages = append(messages, &errdetails.ResourceInfo{
+			ResourceType: resourceInfo.ResourceType,
+			ResourceName: resourceInfo.ResourceName,
+			Owner:        resourceInfo.Owner,
+			Description:  resourceInfo.Description,
+		})
+	}
+
+	return messages
+}
+
+// decodeDetails - decodes google.rpc.Status details into a common error code and details.
+//
+// ok is false if no common error code was encoded in the status details.
+func decodeDetails(statusDetails []interface{}) (code int32, details *Details, ok bool) {
+	details = &Details{}
+
+	for _, statusDetail := range statusDetails {
+		switch detail := statusDetail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.GetDomain() != ErrorInfoDomain {
+				continue
+			}
+
+			parsedCode, err := strconv.ParseInt(detail.GetReason(), 10, 32)
+			if err != nil {
+				continue
+			}
+
+			code, ok = int32(parsedCode), true
+
+			if len(detail.GetMetadata()) > 0 {
+				details.Metadata = detail.GetMetadata()
+			}
+		case *errdetails.RetryInfo:
+			details.RetryInfo = &RetryInfo{
+				RetryDelay: detail.GetRetryDelay().AsDuration(),
+			}
+		case *errdetails.LocalizedMessage:
+			details.LocalizedMessage = &LocalizedMessage{
+				Locale:  detail.GetLocale(),
+				Message: detail.GetMessage(),
+			}
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				details.FieldViolations = append(details.FieldViolations, &FieldViolation{
+					Field:       violation.GetField(),
+					Description: violation.GetDescription(),
+				})
+			}
+		case *errdetails.ResourceInfo:
+			details.ResourceInfos = append(details.ResourceInfos, &ResourceInfo{
+				ResourceType: detail.GetResourceType(),
+				ResourceName: detail.GetResourceName(),
+				Owner:        detail.GetOwner(),
+				Description:  detail.GetDescription(),
+			})
+		default:
+		}
+	}
+
+	if details.isEmpty() {
+		details = nil
+	}
+
+	return code, details, ok
+}
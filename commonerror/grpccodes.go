@@ -0,0 +1,52 @@
+package commonerror
+
+import "google.golang.org/grpc/codes"
+
+// grpcToCommonErrCodes - maps every grpc code to a common error code.
+var grpcToCommonErrCodes = map[codes.Code]int32{
+	codes.OK:                 CodeOk,
+	codes.Canceled:           ErrCodeCanceled,
+	codes.Unknown:            ErrCodeUnknown,
+	codes.InvalidArgument:    ErrCodeInvalidArgument,
+	codes.DeadlineExceeded:   ErrCodeTimeout,
+	codes.NotFound:           ErrCodeNotFound,
+	codes.AlreadyExists:      ErrCodeAlreadyExists,
+	codes.PermissionDenied:   ErrCodePermissionDenied,
+	codes.ResourceExhausted:  ErrCodeResourceExhausted,
+	codes.FailedPrecondition: ErrCodeFailedPrecondition,
+	codes.Aborted:            ErrCodeAborted,
+	codes.OutOfRange:         ErrCodeOutOfRange,
+	codes.Unimplemented:      ErrCodeUnimplemented,
+	codes.Internal:           ErrCodeServer,
+	codes.Unavailable:        ErrCodeUnavailable,
+	codes.DataLoss:           ErrCodeDataLoss,
+	codes.Unauthenticated:    ErrCodeUnauthenticated,
+}
+
+// GRPCToCommonErrCode - returns the common error code of a grpc code.
+//
+// grpc codes without a mapping are treated as ErrCodeUnknown.
+func GRPCToCommonErrCode(code codes.Code) int32 {
+	if commonErrCode, ok := grpcToCommonErrCodes[code]; ok {
+		return commonErrCode
+	}
+
+	return ErrCodeUnknown
+}
+
+// CommonErrCodeToGRPC - returns the grpc code of a common error code as
+// registered in its code definition.
+//
+// Unregistered common error codes, or those registered without a grpc code,
+// are treated as codes.Unknown.
+func CommonErrCodeToGRPC(code int32) codes.Code {
+	if code == CodeOk {
+		return codes.OK
+	}
+
+	if definition, ok := Lookup(code); ok && definition.GRPCCode != codes.OK {
+		return definition.GRPCCode
+	}
+
+	return codes.Unknown
+}
@@ -0,0 +1,39 @@
+package commonerror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestEveryGRPCCodeRoundTrip(t *testing.T) {
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		assert.Equal(t, code, CommonErrCodeToGRPC(GRPCToCommonErrCode(code)), code.String())
+	}
+}
+
+func TestGRPCCodesCollisionFree(t *testing.T) {
+	commonErrCodes := map[int32]codes.Code{}
+
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		commonErrCode := GRPCToCommonErrCode(code)
+
+		existingCode, ok := commonErrCodes[commonErrCode]
+		assert.False(t, ok, "%s and %s share common error code %d", code, existingCode, commonErrCode)
+
+		commonErrCodes[commonErrCode] = code
+	}
+}
+
+func TestInvalidArgumentNotUnknown(t *testing.T) {
+	commonError := New(ErrCodeInvalidArgument, ErrMsgInvalidArgument)
+
+	assert.Equal(t, codes.InvalidArgument, commonError.GRPCCode())
+	assert.NotEqual(t, int32(ErrCodeUnknown), GRPCToCommonErrCode(codes.InvalidArgument))
+}
+
+func TestUnmappedCodes(t *testing.T) {
+	assert.Equal(t, int32(ErrCodeUnknown), GRPCToCommonErrCode(codes.Code(100)))
+	assert.Equal(t, codes.Unknown, CommonErrCodeToGRPC(100))
+}
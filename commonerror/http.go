@@ -0,0 +1,208 @@
+package commonerror
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Problem - RFC 7807 problem details of a common error.
+type Problem struct {
+	Type          string          `json:"type"`
+	Title         string          `json:"title"`
+	Detail        string          `json:"detail,omitempty"`
+	TraceID       string          `json:"traceId,omitempty"`
+	InvalidParams []*InvalidParam `json:"invalid-params,omitempty"`
+	Status        int             `json:"status"`
+	Code          int32           `json:"code"`
+}
+
+// InvalidParam - RFC 7807 extension describing an invalid request field.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// CommonErrCodeToHTTPStatus - returns the http status of a common error code
+// as registered in its code definition.
+//
+// Unregistered common error codes, or those registered without a http status,
+// are treated as http.StatusInternalServerError.
+func CommonErrCodeToHTTPStatus(code int32) int {
+	if code == CodeOk {
+		return http.StatusOK
+	}
+
+	if definition, ok := Lookup(code); ok && definition.HTTPStatus != 0 {
+		return definition.HTTPStatus
+	}
+
+	return http.StatusInternalServerError
+}
+
+// HTTPStatusToCommonErrCode - returns the common error code of a http status
+// for responses without problem details.
+func HTTPStatusToCommonErrCode(httpStatus int) int32 {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return ErrCodeInvalidArgument
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthenticated
+	case http.StatusForbidden:
+		return ErrCodePermissionDenied
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusConflict:
+		return ErrCodeAlreadyExists
+	case http.StatusTooManyRequests:
+		return ErrCodeResourceExhausted
+	case StatusClientClosedRequest:
+		return ErrCodeCanceled
+	case http.StatusNotImplemented:
+		return ErrCodeUnimplemented
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	case http.StatusGatewayTimeout:
+		return ErrCodeTimeout
+	default:
+	}
+
+	if httpStatus >= http.StatusInternalServerError {
+		return ErrCodeServer
+	}
+
+	return ErrCodeUnknown
+}
+
+// HTTPStatus - returns http status mapped from common error code
+func (ce *CommonError) HTTPStatus() int {
+	return CommonErrCodeToHTTPStatus(ce.code)
+}
+
+// NewProblem - builds RFC 7807 problem details of commonError, or of a server
+// error if commonError is nil.
+//
+// detail is the localized user-facing msg if present, otherwise Msg().
+func NewProblem(commonError ICommonError, traceID string) *Problem {
+	commonError = orServerError(commonError)
+	httpStatus := CommonErrCodeToHTTPStatus(commonError.Code())
+	problem := &Problem{
+		Type:    ProblemTypeDefault,
+		Title:   http.StatusText(httpStatus),
+		Status:  httpStatus,
+		Detail:  commonError.Msg(),
+		Code:    commonError.Code(),
+		TraceID: traceID,
+	}
+
+	if definition, ok := Lookup(commonError.Code()); ok {
+		problem.Type = fmt.Sprintf(ProblemTypeFormat, definition.Namespace, strings.ToLower(definition.Name))
+
+		if definition.Msg != "" {
+			problem.Title = definition.Msg
+		}
+	}
+
+	details := commonError.Details()
+	if details == nil {
+		return problem
+	}
+
+	if details.LocalizedMessage != nil {
+		problem.Detail = details.LocalizedMessage.Message
+	}
+
+	for _, violation := range details.FieldViolations {
+		problem.InvalidParams = append(problem.InvalidParams, &InvalidParam{
+			Name:   violation.Field,
+			Reason: violation.Description,
+		})
+	}
+
+	return problem
+}
+
+// orServerError - returns commonError, or a server error if commonError is nil.
+func orServerError(commonError ICommonError) ICommonError {
+	if commonError == nil || commonError == (*CommonError)(nil) {
+		return New(ErrCodeServer, ErrMsgServer)
+	}
+
+	return commonError
+}
+
+// WriteProblem - writes commonError as an application/problem+json response.
+//
+// The Retry-After header is set for common errors retryable after a delay.
+func WriteProblem(w http.ResponseWriter, commonError ICommonError, traceID string) error {
+	commonError = orServerError(commonError)
+	problem := NewProblem(commonError, traceID)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+
+	if commonError.Retryability() == RetryableAfter {
+		retryAfter := int64((commonError.RetryDelay() + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	}
+
+	w.WriteHeader(problem.Status)
+
+	return json.NewEncoder(w).Encode(problem)
+}
+
+// ParseProblem - converts a http response into a common error, nil if the
+// response is successful.
+//
+// application/problem+json bodies are decoded back into the common error code,
+// detail and invalid params. Other error responses are converted by their
+// http status. The response body is read but not closed.
+func ParseProblem(resp *http.Response) (ICommonError, error) {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil, nil
+	}
+
+	details := &Details{}
+
+	if retryAfter, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64); err == nil {
+		details.RetryInfo = &RetryInfo{RetryDelay: time.Duration(retryAfter) * time.Second}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != ProblemContentType {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		if details.isEmpty() {
+			details = nil
+		}
+
+		return NewWithDetails(HTTPStatusToCommonErrCode(resp.StatusCode), http.StatusText(resp.StatusCode), details), nil
+	}
+
+	problem := &Problem{}
+	if err := json.NewDecoder(resp.Body).Decode(problem); err != nil {
+		return nil, fmt.Errorf("fail to decode problem: %w", err)
+	}
+
+	code := problem.Code
+	if code == CodeOk {
+		code = HTTPStatusToCommonErrCode(resp.StatusCode)
+	}
+
+	for _, invalidParam := range problem.InvalidParams {
+		details.FieldViolations = append(details.FieldViolations, &FieldViolation{
+			Field:       invalidParam.Name,
+			Description: invalidParam.Reason,
+		})
+	}
+
+	if details.isEmpty() {
+		details = nil
+	}
+
+	return NewWithDetails(code, problem.Detail, details), nil
+}
@@ -0,0 +1,88 @@
+package commonerror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEveryBuiltinCodeHasHTTPStatus(t *testing.T) {
+	for _, definition := range builtinDefinitions {
+		assert.NotZero(t, definition.HTTPStatus, definition.Name)
+	}
+
+	assert.Equal(t, http.StatusNotFound, New(ErrCodeNotFound, ErrMsgNotFound).HTTPStatus())
+	assert.Equal(t, http.StatusInternalServerError, CommonErrCodeToHTTPStatus(100))
+}
+
+func TestWriteProblem(t *testing.T) {
+	commonError := NewWithDetails(ErrCodeInvalidArgument, "email is invalid", &Details{
+		FieldViolations: []*FieldViolation{{Field: "email", Description: "invalid format"}},
+	})
+	recorder := httptest.NewRecorder()
+
+	assert.Nil(t, WriteProblem(recorder, commonError, "trace1"))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, ProblemContentType, recorder.Header().Get("Content-Type"))
+
+	problem := &Problem{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), problem))
+	assert.Equal(t, &Problem{
+		Type:          "urn:problem-type:common:invalid_argument",
+		Title:         ErrMsgInvalidArgument,
+		Status:        http.StatusBadRequest,
+		Detail:        "email is invalid",
+		Code:          ErrCodeInvalidArgument,
+		TraceID:       "trace1",
+		InvalidParams: []*InvalidParam{{Name: "email", Reason: "invalid format"}},
+	}, problem)
+}
+
+func TestNilProblem(t *testing.T) {
+	problem := NewProblem(nil, "trace1")
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Equal(t, int32(ErrCodeServer), problem.Code)
+	assert.Equal(t, ErrMsgServer, problem.Detail)
+	assert.Equal(t, "trace1", problem.TraceID)
+
+	var commonError *CommonError
+	recorder := httptest.NewRecorder()
+
+	assert.Nil(t, WriteProblem(recorder, commonError, ""))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestParseProblemRoundTrip(t *testing.T) {
+	commonError := NewWithDetails(ErrCodeResourceExhausted, "too many orders", &Details{
+		RetryInfo:       &RetryInfo{RetryDelay: 2 * time.Second},
+		FieldViolations: []*FieldViolation{{Field: "quantity", Description: "too large"}},
+	})
+	recorder := httptest.NewRecorder()
+
+	assert.Nil(t, WriteProblem(recorder, commonError, ""))
+
+	commonErrorParse, err := ParseProblem(recorder.Result())
+	assert.Nil(t, err)
+	assert.Equal(t, commonError, commonErrorParse)
+}
+
+func TestParseNonProblemResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusServiceUnavailable)
+
+	commonError, err := ParseProblem(recorder.Result())
+	assert.Nil(t, err)
+	assert.Equal(t, int32(ErrCodeUnavailable), commonError.Code())
+	assert.Equal(t, Retryable, commonError.Retryability())
+
+	recorder = httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusOK)
+
+	commonError, err = ParseProblem(recorder.Result())
+	assert.Nil(t, err)
+	assert.Nil(t, commonError)
+}
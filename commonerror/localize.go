@@ -0,0 +1,146 @@
+package commonerror
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// messageCatalog - holds localized user-facing msgs keyed by locale and code.
+type messageCatalog struct {
+	messages map[string]map[int32]string
+	mu       sync.RWMutex
+}
+
+var catalog = &messageCatalog{
+	messages: make(map[string]map[int32]string),
+}
+
+// RegisterMessages - registers localized user-facing msgs of common error codes
+// for locale, e.g. "en", "zh-TW".
+//
+// Should be called at init time. Msgs registered later for the same locale and
+// code overwrite earlier ones.
+func RegisterMessages(locale string, messages map[int32]string) {
+	locale = normalizeLocale(locale)
+
+	catalog.mu.Lock()
+	defer catalog.mu.Unlock()
+
+	localeMessages, ok := catalog.messages[locale]
+	if !ok {
+		localeMessages = make(map[int32]string)
+		catalog.messages[locale] = localeMessages
+	}
+
+	for code, msg := range messages {
+		localeMessages[code] = msg
+	}
+}
+
+// LocalizedMsg - returns the localized user-facing msg of code for locale and
+// the locale it was found in.
+//
+// Falls back to the base language of locale, e.g. "zh" for "zh-TW", and then
+// to DefaultLocale.
+func LocalizedMsg(code int32, locale string) (msg, foundLocale string, ok bool) {
+	catalog.mu.RLock()
+	defer catalog.mu.RUnlock()
+
+	for _, candidate := range fallbackLocales(locale) {
+		if msg, ok = catalog.messages[candidate][code]; ok {
+			return msg, candidate, true
+		}
+	}
+
+	return "", "", false
+}
+
+// LocaleFromContext - returns the locale of an incoming grpc request, read from
+// the LocaleMetadataKey metadata, or else the first language of the
+// AcceptLanguageMetadataKey metadata.
+//
+// Returns DefaultLocale if neither is present.
+func LocaleFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return DefaultLocale
+	}
+
+	if values := md.Get(LocaleMetadataKey); len(values) > 0 && values[0] != "" {
+		return normalizeLocale(values[0])
+	}
+
+	if values := md.Get(AcceptLanguageMetadataKey); len(values) > 0 && values[0] != "" {
+		language := strings.Split(values[0], ",")[0]
+		language = strings.Split(language, ";")[0]
+
+		if language = strings.TrimSpace(language); language != "" && language != "*" {
+			return normalizeLocale(language)
+		}
+	}
+
+	return DefaultLocale
+}
+
+// Localize - returns a copy of commonError with the localized user-facing msg
+// for the locale of ctx added to its details.
+//
+// Msg() of the returned common error remains the internal debug msg. commonError
+// is returned as is if it has no localized msg.
+func Localize(ctx context.Context, commonError ICommonError) ICommonError {
+	ce, ok := commonError.(*CommonError)
+	if !ok || ce == nil {
+		return commonError
+	}
+
+	msg, locale, ok := LocalizedMsg(ce.code, LocaleFromContext(ctx))
+	if !ok {
+		return commonError
+	}
+
+	details := &Details{}
+	if ce.details != nil {
+		*details = *ce.details
+	}
+
+	details.LocalizedMessage = &LocalizedMessage{
+		Locale:  locale,
+		Message: msg,
+	}
+
+	localizedErr := *ce
+	localizedErr.details = details
+
+	return &localizedErr
+}
+
+// normalizeLocale - normalizes locale into the form "zh-TW".
+func normalizeLocale(locale string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i])
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// fallbackLocales - returns the locales to look up in order for locale.
+func fallbackLocales(locale string) []string {
+	locale = normalizeLocale(locale)
+	locales := []string{locale}
+
+	if idx := strings.Index(locale, "-"); idx > 0 {
+		locales = append(locales, locale[:idx])
+	}
+
+	if locale != DefaultLocale {
+		locales = append(locales, DefaultLocale)
+	}
+
+	return locales
+}
@@ -0,0 +1,62 @@
+package commonerror
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func init() {
+	RegisterMessages("en", map[int32]string{
+		ErrCodeNotFound: "We could not find what you were looking for.",
+	})
+	RegisterMessages("zh", map[int32]string{
+		ErrCodeNotFound: "找不到您要的内容。",
+	})
+	RegisterMessages("zh_tw", map[int32]string{
+		ErrCodeNotFound: "找不到您要的內容。",
+	})
+}
+
+func TestLocalizedMsgFallback(t *testing.T) {
+	msg, locale, ok := LocalizedMsg(ErrCodeNotFound, "zh-TW")
+	assert.True(t, ok)
+	assert.Equal(t, "zh-TW", locale)
+	assert.Equal(t, "找不到您要的內容。", msg)
+
+	_, locale, ok = LocalizedMsg(ErrCodeNotFound, "zh-SG")
+	assert.True(t, ok)
+	assert.Equal(t, "zh", locale)
+
+	_, locale, ok = LocalizedMsg(ErrCodeNotFound, "fr-FR")
+	assert.True(t, ok)
+	assert.Equal(t, DefaultLocale, locale)
+
+	_, _, ok = LocalizedMsg(ErrCodeDataLoss, "fr-FR")
+	assert.False(t, ok)
+}
+
+func TestLocaleFromContext(t *testing.T) {
+	assert.Equal(t, DefaultLocale, LocaleFromContext(context.Background()))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(LocaleMetadataKey, "zh_tw"))
+	assert.Equal(t, "zh-TW", LocaleFromContext(ctx))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(AcceptLanguageMetadataKey, "fr-CH, fr;q=0.9"))
+	assert.Equal(t, "fr-CH", LocaleFromContext(ctx))
+}
+
+func TestLocalizeKeepsDebugMsgAndRoundTrips(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(LocaleMetadataKey, "zh-TW"))
+	commonError := New(ErrCodeNotFound, "order 123 missing from db")
+	localizedError := Localize(ctx, commonError)
+
+	assert.Nil(t, commonError.Details())
+	assert.Equal(t, commonError.Msg(), localizedError.Msg())
+	assert.Equal(t, &LocalizedMessage{Locale: "zh-TW", Message: "找不到您要的內容。"}, localizedError.Details().LocalizedMessage)
+
+	commonErrorConvert := Convert(localizedError.GRPCStatus().Err())
+	assert.Equal(t, localizedError.Details(), commonErrorConvert.Details())
+}
@@ -0,0 +1,191 @@
+package commonerror
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// CodeDefinition - describes a common error code registered by a service.
+type CodeDefinition struct {
+	Namespace  string
+	Name       string
+	Msg        string // default msg of the code
+	HTTPStatus int
+	GRPCCode   codes.Code // codes.OK is treated as codes.Unknown
+	Code       int32
+	Retryable  bool // common errors of the code are populated with retry info
+}
+
+// codeRange - range of codes owned by a namespace, inclusive of min and max.
+type codeRange struct {
+	namespace string
+	min       int32
+	max       int32
+}
+
+// codeRegistry - holds registered namespaces and code definitions.
+type codeRegistry struct {
+	namespaces  map[string]*codeRange
+	definitions map[int32]*CodeDefinition
+	names       map[string]int32
+	mu          sync.RWMutex
+}
+
+var registry = &codeRegistry{
+	namespaces:  make(map[string]*codeRange),
+	definitions: make(map[int32]*CodeDefinition),
+	names:       make(map[string]int32),
+}
+
+// builtinDefinitions - definitions of common error codes provided by this package.
+var builtinDefinitions = []CodeDefinition{
+	{Code: ErrCodeGRPC, Name: "GRPC", Msg: ErrMsgUnavailable, HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable, Retryable: true},
+	{Code: ErrCodeServer, Name: "SERVER", Msg: ErrMsgServer, HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.Internal},
+	{Code: ErrCodeUnknown, Name: "UNKNOWN", Msg: ErrMsgUnknown, HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.Unknown},
+	{Code: ErrCodeTimeout, Name: "TIMEOUT", Msg: ErrMsgTimeout, HTTPStatus: http.StatusGatewayTimeout, GRPCCode: codes.DeadlineExceeded, Retryable: true},
+	{Code: ErrCodeCanceled, Name: "CANCELED", Msg: ErrMsgCanceled, HTTPStatus: StatusClientClosedRequest, GRPCCode: codes.Canceled},
+	{Code: ErrCodeInvalidArgument, Name: "INVALID_ARGUMENT", Msg: ErrMsgInvalidArgument, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument},
+	{Code: ErrCodeNotFound, Name: "NOT_FOUND", Msg: ErrMsgNotFound, HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound},
+	{Code: ErrCodeAlreadyExists, Name: "ALREADY_EXISTS", Msg: ErrMsgAlreadyExists, HTTPStatus: http.StatusConflict, GRPCCode: codes.AlreadyExists},
+	{Code: ErrCodePermissionDenied, Name: "PERMISSION_DENIED", Msg: ErrMsgPermissionDenied, HTTPStatus: http.StatusForbidden, GRPCCode: codes.PermissionDenied},
+	{Code: ErrCodeResourceExhausted, Name: "RESOURCE_EXHAUSTED", Msg: ErrMsgResourceExhausted, HTTPStatus: http.StatusTooManyRequests, GRPCCode: codes.ResourceExhausted},
+	{Code: ErrCodeFailedPrecondition, Name: "FAILED_PRECONDITION", Msg: ErrMsgFailedPrecondition, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.FailedPrecondition},
+	{Code: ErrCodeAborted, Name: "ABORTED", Msg: ErrMsgAborted, HTTPStatus: http.StatusConflict, GRPCCode: codes.Aborted},
+	{Code: ErrCodeOutOfRange, Name: "OUT_OF_RANGE", Msg: ErrMsgOutOfRange, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.OutOfRange},
+	{Code: ErrCodeUnimplemented, Name: "UNIMPLEMENTED", Msg: ErrMsgUnimplemented, HTTPStatus: http.StatusNotImplemented, GRPCCode: codes.Unimplemented},
+	{Code: ErrCodeUnavailable, Name: "UNAVAILABLE", Msg: ErrMsgUnavailable, HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable, Retryable: true},
+	{Code: ErrCodeDataLoss, Name: "DATA_LOSS", Msg: ErrMsgDataLoss, HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.DataLoss},
+	{Code: ErrCodeUnauthenticated, Name: "UNAUTHENTICATED", Msg: ErrMsgUnauthenticated, HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated},
+	{Code: ErrCodeCircuitOpen, Name: "CIRCUIT_OPEN", Msg: ErrMsgCircuitOpen, HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable},
+}
+
+func init() {
+	RegisterNamespace(CommonNamespace, ReservedCodeMin, ReservedCodeMax)
+
+	for _, definition := range builtinDefinitions {
+		definition.Namespace = CommonNamespace
+		Register(definition)
+	}
+}
+
+// RegisterNamespace - reserves the range of codes [minCode, maxCode] for namespace.
+//
+// Should be called at init time. Panics if namespace is already registered or
+// its range overlaps with that of another namespace.
+func RegisterNamespace(namespace string, minCode, maxCode int32) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if minCode > maxCode || minCode <= CodeOk {
+		panic(fmt.Sprintf("commonerror: invalid code range [%d, %d] for namespace %s", minCode, maxCode, namespace))
+	}
+
+	if _, ok := registry.namespaces[namespace]; ok {
+		panic(fmt.Sprintf("commonerror: duplicate namespace %s", namespace))
+	}
+
+	for _, existingRange := range registry.namespaces {
+		if minCode <= existingRange.max && existingRange.min <= maxCode {
+			panic(fmt.Sprintf(
+				"commonerror: code range [%d, %d] of namespace %s overlaps with [%d, %d] of namespace %s",
+				minCode, maxCode, namespace, existingRange.min, existingRange.max, existingRange.namespace,
+			))
+		}
+	}
+
+	registry.namespaces[namespace] = &codeRange{
+		namespace: namespace,
+		min:       minCode,
+		max:       maxCode,
+	}
+}
+
+// Register - registers a code definition under its namespace.
+//
+// Should be called at init time. Panics if the namespace is not registered,
+// the code is outside of the namespace's range, or the code or name is
+// already registered.
+func Register(definition CodeDefinition) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	namespaceRange, ok := registry.namespaces[definition.Namespace]
+	if !ok {
+		panic(fmt.Sprintf("commonerror: unregistered namespace %s for code %d", definition.Namespace, definition.Code))
+	}
+
+	if definition.Code < namespaceRange.min || definition.Code > namespaceRange.max {
+		panic(fmt.Sprintf(
+			"commonerror: code %d outside of range [%d, %d] of namespace %s",
+			definition.Code, namespaceRange.min, namespaceRange.max, definition.Namespace,
+		))
+	}
+
+	if existing, ok := registry.definitions[definition.Code]; ok {
+		panic(fmt.Sprintf("commonerror: duplicate code %d, already registered as %s.%s",
+			definition.Code, existing.Namespace, existing.Name))
+	}
+
+	qualifiedName := definition.Namespace + "." + definition.Name
+	if _, ok := registry.names[qualifiedName]; ok {
+		panic(fmt.Sprintf("commonerror: duplicate name %s", qualifiedName))
+	}
+
+	registry.definitions[definition.Code] = &definition
+	registry.names[qualifiedName] = definition.Code
+}
+
+// Lookup - returns the registered definition of code.
+func Lookup(code int32) (CodeDefinition, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	definition, ok := registry.definitions[code]
+	if !ok {
+		return CodeDefinition{}, false
+	}
+
+	return *definition, true
+}
+
+// LookupName - returns the registered definition of name within namespace.
+func LookupName(namespace, name string) (CodeDefinition, bool) {
+	registry.mu.RLock()
+	code, ok := registry.names[namespace+"."+name]
+	registry.mu.RUnlock()
+
+	if !ok {
+		return CodeDefinition{}, false
+	}
+
+	return Lookup(code)
+}
+
+// Definitions - returns all registered definitions ordered by code.
+func Definitions() []CodeDefinition {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	definitions := make([]CodeDefinition, 0, len(registry.definitions))
+	for _, definition := range registry.definitions {
+		definitions = append(definitions, *definition)
+	}
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Code < definitions[j].Code
+	})
+
+	return definitions
+}
+
+// NewFromCode - initializes a new common error with the default msg of a
+// registered code.
+func NewFromCode(code int32) ICommonError {
+	definition, _ := Lookup(code)
+
+	return New(code, definition.Msg)
+}
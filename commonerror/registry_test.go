@@ -0,0 +1,82 @@
+package commonerror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestBuiltinCodesRegistered(t *testing.T) {
+	definition, ok := Lookup(ErrCodeInvalidArgument)
+
+	assert.True(t, ok)
+	assert.Equal(t, CommonNamespace, definition.Namespace)
+	assert.Equal(t, codes.InvalidArgument, definition.GRPCCode)
+}
+
+func TestRegisterAndLookup(t *testing.T) {
+	RegisterNamespace("orderservice", 10000, 10999)
+	Register(CodeDefinition{
+		Namespace:  "orderservice",
+		Name:       "ORDER_NOT_FOUND",
+		Msg:        "order not found",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+		Code:       10001,
+	})
+
+	definition, ok := Lookup(10001)
+	assert.True(t, ok)
+	assert.Equal(t, "ORDER_NOT_FOUND", definition.Name)
+
+	definition, ok = LookupName("orderservice", "ORDER_NOT_FOUND")
+	assert.True(t, ok)
+	assert.Equal(t, int32(10001), definition.Code)
+
+	commonError := NewFromCode(10001)
+	assert.Equal(t, "order not found", commonError.Msg())
+	assert.Equal(t, codes.NotFound, commonError.GRPCCode())
+}
+
+func TestRegisterDuplicateCodePanics(t *testing.T) {
+	RegisterNamespace("paymentservice", 11000, 11999)
+	Register(CodeDefinition{Namespace: "paymentservice", Name: "DECLINED", Code: 11001})
+
+	assert.Panics(t, func() {
+		Register(CodeDefinition{Namespace: "paymentservice", Name: "EXPIRED", Code: 11001})
+	})
+	assert.Panics(t, func() {
+		Register(CodeDefinition{Namespace: "paymentservice", Name: "DECLINED", Code: 11002})
+	})
+}
+
+func TestRegisterOutsideRangePanics(t *testing.T) {
+	RegisterNamespace("userservice", 12000, 12999)
+
+	assert.Panics(t, func() {
+		Register(CodeDefinition{Namespace: "userservice", Name: "BANNED", Code: 13000})
+	})
+	assert.Panics(t, func() {
+		Register(CodeDefinition{Namespace: "missingservice", Name: "BANNED", Code: 12001})
+	})
+}
+
+func TestRegisterNamespaceOverlapPanics(t *testing.T) {
+	RegisterNamespace("cartservice", 14000, 14999)
+
+	assert.Panics(t, func() { RegisterNamespace("cartservice", 15000, 15999) })
+	assert.Panics(t, func() { RegisterNamespace("wishlistservice", 14500, 15500) })
+	assert.Panics(t, func() { RegisterNamespace("wishlistservice", ReservedCodeMin, ReservedCodeMin) })
+}
+
+func TestCircuitOpenCode(t *testing.T) {
+	commonError := NewFromCode(ErrCodeCircuitOpen)
+
+	assert.Equal(t, ErrMsgCircuitOpen, commonError.Msg())
+	assert.Equal(t, codes.Unavailable, commonError.GRPCCode())
+	assert.Equal(t, http.StatusServiceUnavailable, commonError.HTTPStatus())
+	assert.Equal(t, NonRetryable, commonError.Retryability())
+	assert.Equal(t, int32(ErrCodeCircuitOpen), Convert(commonError.GRPCStatus().Err()).Code())
+}
@@ -0,0 +1,72 @@
+package commonerror
+
+import "time"
+
+// Retryability - classifies whether a request failing with a common error
+// may be retried.
+type Retryability int
+
+const (
+	NonRetryable Retryability = iota
+	Retryable
+	RetryableAfter // retryable after RetryDelay
+)
+
+// String - returns name of retryability
+func (r Retryability) String() string {
+	switch r {
+	case Retryable:
+		return "retryable"
+	case RetryableAfter:
+		return "retryable after delay"
+	default:
+		return "non-retryable"
+	}
+}
+
+// Retryability - returns whether the request may be retried, as determined
+// by the retry info of the common error
+func (ce *CommonError) Retryability() Retryability {
+	if ce.details == nil || ce.details.RetryInfo == nil {
+		return NonRetryable
+	}
+
+	if ce.details.RetryInfo.RetryDelay > 0 {
+		return RetryableAfter
+	}
+
+	return Retryable
+}
+
+// RetryDelay - returns the minimum delay before the request may be retried
+func (ce *CommonError) RetryDelay() time.Duration {
+	if ce.details == nil || ce.details.RetryInfo == nil {
+		return 0
+	}
+
+	return ce.details.RetryInfo.RetryDelay
+}
+
+// withDefaultRetryInfo - returns details with retry info populated if code is
+// registered as retryable and details do not already contain retry info.
+//
+// details provided are copied instead of modified.
+func withDefaultRetryInfo(code int32, details *Details) *Details {
+	if details != nil && details.RetryInfo != nil {
+		return details
+	}
+
+	definition, ok := Lookup(code)
+	if !ok || !definition.Retryable {
+		return details
+	}
+
+	retryDetails := &Details{}
+	if details != nil {
+		*retryDetails = *details
+	}
+
+	retryDetails.RetryInfo = &RetryInfo{}
+
+	return retryDetails
+}
@@ -0,0 +1,47 @@
+package commonerror
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTimeoutAndUnavailableRetryable(t *testing.T) {
+	assert.Equal(t, Retryable, New(ErrCodeTimeout, ErrMsgTimeout).Retryability())
+	assert.Equal(t, Retryable, New(ErrCodeUnavailable, ErrMsgUnavailable).Retryability())
+	assert.Equal(t, Retryable, Convert(status.Error(codes.Unavailable, ErrMsgUnavailable)).Retryability())
+}
+
+func TestServerErrorNonRetryable(t *testing.T) {
+	assert.Equal(t, NonRetryable, New(ErrCodeServer, ErrMsgServer).Retryability())
+	assert.Equal(t, NonRetryable, New(ErrCodeInvalidArgument, ErrMsgInvalidArgument).Retryability())
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	commonError := NewWithDetails(ErrCodeResourceExhausted, ErrMsgResourceExhausted, &Details{
+		RetryInfo: &RetryInfo{RetryDelay: 3 * time.Second},
+	})
+
+	assert.Equal(t, RetryableAfter, commonError.Retryability())
+	assert.Equal(t, 3*time.Second, commonError.RetryDelay())
+}
+
+func TestRetryInfoRoundTrip(t *testing.T) {
+	commonError := NewWithDetails(ErrCodeTimeout, ErrMsgTimeout, &Details{
+		Metadata: map[string]string{"orderId": "123"},
+	})
+	commonErrorConvert := Convert(commonError.GRPCStatus().Err())
+
+	assert.Equal(t, Retryable, commonErrorConvert.Retryability())
+	assert.Equal(t, commonError.Details(), commonErrorConvert.Details())
+}
+
+func TestDefaultRetryInfoDoesNotModifyDetails(t *testing.T) {
+	details := &Details{Metadata: map[string]string{"orderId": "123"}}
+	_ = NewWithDetails(ErrCodeTimeout, ErrMsgTimeout, details)
+
+	assert.Nil(t, details.RetryInfo)
+}
@@ -0,0 +1,161 @@
+package errortype
+
+import (
+	"errors"
+
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/grpc/status"
+)
+
+// ToCommonError - converts err into a common error using the outermost mapped
+// ErrorType within err's chain.
+//
+// The common error has the CommonCode of the ErrorType and its PublicMsg, or
+// else the default msg of the common error code, so that internal details of
+// packages are hidden from callers. err is kept as the cause of the common error.
+//
+// If a common error is found in err's chain before any mapped ErrorType, it is
+// returned instead. MultiErrors are converted with a field violation per item.
+// If neither is found, err is converted by commonerror.Convert if it carries a
+// grpc status, otherwise into ErrCodeServer.
+func ToCommonError(err error) commonerror.ICommonError {
+	return toCommonError(err, false)
+}
+
+// ToCommonErrorWithInternals - same as ToCommonError, but the msg of the common
+// error is err's Error(), exposing internal details of packages.
+//
+// Should only be used for callers within the same trust boundary.
+func ToCommonErrorWithInternals(err error) commonerror.ICommonError {
+	return toCommonError(err, true)
+}
+
+func toCommonError(err error, withInternals bool) commonerror.ICommonError {
+	if err == nil {
+		return nil
+	}
+
+	var commonError commonerror.ICommonError
+
+	walkChain(err, func(chainErr error) bool {
+		switch typedErr := chainErr.(type) {
+		case *commonerror.CommonError:
+			commonError = typedErr
+		case *MultiError:
+			commonError = typedErr.toCommonError(withInternals)
+
+			return commonError != nil
+		case *Error:
+			if typedErr.detail.CommonCode == commonerror.CodeOk {
+				return false
+			}
+
+			msg := publicMsg(typedErr.detail)
+			if withInternals {
+				msg = err.Error()
+			}
+
+			commonError = commonerror.Wrap(typedErr.detail.CommonCode, msg, err)
+		default:
+			return false
+		}
+
+		return true
+	})
+
+	if commonError != nil {
+		return commonError
+	}
+
+	var grpcStatusErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcStatusErr) {
+		return commonerror.Convert(err)
+	}
+
+	msg := commonerror.ErrMsgServer
+	if withInternals {
+		msg = err.Error()
+	}
+
+	return commonerror.Wrap(commonerror.ErrCodeServer, msg, err)
+}
+
+// toCommonError - converts MultiError into a common error with the common error
+// code of its first item, and a field violation per item describing the msg of
+// the item's converted common error.
+func (m *MultiError) toCommonError(withInternals bool) commonerror.ICommonError {
+	items := m.Items()
+	if len(items) == 0 {
+		return nil
+	}
+
+	code := int32(commonerror.ErrCodeServer)
+	details := &commonerror.Details{
+		FieldViolations: make([]*commonerror.FieldViolation, 0, len(items)),
+	}
+
+	for i, item := range items {
+		itemCommonError := toCommonError(item.Err, withInternals)
+		if i == 0 {
+			code = itemCommonError.Code()
+		}
+
+		details.FieldViolations = append(details.FieldViolations, &commonerror.FieldViolation{
+			Field:       item.id(),
+			Description: itemCommonError.Msg(),
+		})
+	}
+
+	msg := defaultMsg(code)
+	if withInternals {
+		msg = m.Error()
+	}
+
+	return commonerror.NewWithDetails(code, msg, details)
+}
+
+// publicMsg - returns the PublicMsg of errorType, or else the default msg of its
+// common error code.
+func publicMsg(errorType ErrorType) string {
+	if errorType.PublicMsg != "" {
+		return errorType.PublicMsg
+	}
+
+	return defaultMsg(errorType.CommonCode)
+}
+
+// defaultMsg - returns the default msg of a registered common error code.
+func defaultMsg(code int32) string {
+	if definition, ok := commonerror.Lookup(code); ok {
+		return definition.Msg
+	}
+
+	return commonerror.ErrMsgServer
+}
+
+// walkChain - visits err and the errors it wraps in pre-order, so that outer
+// errors are visited first, until visit returns true.
+func walkChain(err error, visit func(error) bool) bool {
+	for err != nil {
+		if visit(err) {
+			return true
+		}
+
+		switch wrapper := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, wrappedErr := range wrapper.Unwrap() {
+				if walkChain(wrappedErr, visit) {
+					return true
+				}
+			}
+
+			return false
+		case interface{ Unwrap() error }:
+			err = wrapper.Unwrap()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package errortype
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var mappedErrorTypeNotFound = ErrorType{
+	Code:       3000,
+	Pkg:        "dummypackage3",
+	CommonCode: commonerror.ErrCodeNotFound,
+	PublicMsg:  "order not found",
+}
+
+var mappedErrorTypeInvalid = ErrorType{
+	Code:       4000,
+	Pkg:        "dummypackage4",
+	CommonCode: commonerror.ErrCodeInvalidArgument,
+}
+
+func TestToCommonErrorOutermostMapped(t *testing.T) {
+	err := mappedErrorTypeInvalid.Wrap(dummyErrorType11.Wrap(mappedErrorTypeNotFound.New("order 123 missing in db")))
+	commonError := ToCommonError(fmt.Errorf("handler: %w", err))
+
+	assert.Equal(t, int32(commonerror.ErrCodeInvalidArgument), commonError.Code())
+	assert.Equal(t, commonerror.ErrMsgInvalidArgument, commonError.Msg())
+	assert.True(t, mappedErrorTypeNotFound.Is(commonError))
+}
+
+func TestToCommonErrorHidesInternals(t *testing.T) {
+	err := dummyErrorType11.Wrap(mappedErrorTypeNotFound.New("order 123 missing in db"))
+	commonError := ToCommonError(err)
+
+	assert.Equal(t, int32(commonerror.ErrCodeNotFound), commonError.Code())
+	assert.Equal(t, "order not found", commonError.Msg())
+	assert.NotContains(t, commonError.GRPCStatus().Message(), "dummypackage")
+
+	commonError = ToCommonErrorWithInternals(err)
+
+	assert.Equal(t, int32(commonerror.ErrCodeNotFound), commonError.Code())
+	assert.Equal(t, err.Error(), commonError.Msg())
+}
+
+func TestToCommonErrorUnmapped(t *testing.T) {
+	commonError := ToCommonError(dummyErrorType11.Wrap(io.EOF))
+
+	assert.Equal(t, int32(commonerror.ErrCodeServer), commonError.Code())
+	assert.Equal(t, commonerror.ErrMsgServer, commonError.Msg())
+	assert.True(t, errors.Is(commonError, io.EOF))
+
+	commonError = ToCommonError(fmt.Errorf("call: %w", status.Error(codes.Unavailable, "downstream")))
+
+	assert.Equal(t, int32(commonerror.ErrCodeUnavailable), commonError.Code())
+	assert.Nil(t, ToCommonError(nil))
+}
+
+func TestToCommonErrorOuterCommonError(t *testing.T) {
+	outerCommonError := commonerror.Wrap(commonerror.ErrCodeAborted, commonerror.ErrMsgAborted, mappedErrorTypeNotFound.New("one"))
+
+	assert.Equal(t, outerCommonError, ToCommonError(outerCommonError))
+}
+
+func TestToCommonErrorMultiError(t *testing.T) {
+	multiErr := NewMultiError()
+	multiErr.AddWithKey("email", mappedErrorTypeInvalid.New("email has no @"))
+	multiErr.AddWithKey("name", dummyErrorType11.New("name is empty"))
+
+	commonError := ToCommonError(multiErr)
+
+	assert.Equal(t, int32(commonerror.ErrCodeInvalidArgument), commonError.Code())
+	assert.Equal(t, []*commonerror.FieldViolation{
+		{Field: "email", Description: commonerror.ErrMsgInvalidArgument},
+		{Field: "name", Description: commonerror.ErrMsgServer},
+	}, commonError.Details().FieldViolations)
+}
@@ -12,3 +12,12 @@ const (
 const (
 	ErrFormat = "error: code=%d, pkg=%s, msg=%s"
 )
+
+const (
+	MaxStackDepth = 32
+)
+
+const (
+	MultiErrFormat = "multi error: count=%d"
+	ItemErrFormat  = "[%s] %s"
+)
@@ -0,0 +1,141 @@
+package errortype
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Declaration - describes a declared ErrorType for the error reference docs.
+type Declaration struct {
+	Pkg         string `json:"pkg"`
+	PublicMsg   string `json:"publicMsg,omitempty"`
+	Description string `json:"description"`
+	location    string
+	Code        int32    `json:"code"`
+	CommonCode  int32    `json:"commonCode,omitempty"`
+	Severity    Severity `json:"severity,omitempty"`
+}
+
+// declarationKey - uniquely identifies an ErrorType.
+type declarationKey struct {
+	pkg  string
+	code int32
+}
+
+// declarationRegistry - holds declarations of ErrorTypes.
+type declarationRegistry struct {
+	declarations map[declarationKey]*Declaration
+	mu           sync.RWMutex
+}
+
+var declarations = &declarationRegistry{
+	declarations: make(map[declarationKey]*Declaration),
+}
+
+// Declare - registers errorType globally with a description and returns it,
+// e.g. var ErrOrderNotFound = errortype.Declare(errortype.ErrorType{...}, "...").
+//
+// Should be called at init time. Panics if an ErrorType of the same Pkg and Code
+// is already declared.
+func Declare(errorType ErrorType, description string) ErrorType {
+	location := "unknown"
+	if _, file, line, ok := runtime.Caller(1); ok {
+		location = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	key := declarationKey{pkg: errorType.Pkg, code: errorType.Code}
+
+	declarations.mu.Lock()
+	defer declarations.mu.Unlock()
+
+	if existing, ok := declarations.declarations[key]; ok {
+		panic(fmt.Sprintf("errortype: duplicate error type pkg=%s, code=%d declared at %s, already declared at %s",
+			errorType.Pkg, errorType.Code, location, existing.location))
+	}
+
+	declarations.declarations[key] = &Declaration{
+		Pkg:         errorType.Pkg,
+		Code:        errorType.Code,
+		CommonCode:  errorType.CommonCode,
+		Severity:    errorType.Severity,
+		PublicMsg:   errorType.PublicMsg,
+		Description: description,
+		location:    location,
+	}
+
+	return errorType
+}
+
+// Catalogue - returns the declarations of all declared ErrorTypes, ordered by
+// Pkg and then by Code.
+func Catalogue() []Declaration {
+	declarations.mu.RLock()
+	defer declarations.mu.RUnlock()
+
+	catalogue := make([]Declaration, 0, len(declarations.declarations))
+	for _, declaration := range declarations.declarations {
+		catalogue = append(catalogue, *declaration)
+	}
+
+	sort.Slice(catalogue, func(i, j int) bool {
+		if catalogue[i].Pkg != catalogue[j].Pkg {
+			return catalogue[i].Pkg < catalogue[j].Pkg
+		}
+
+		return catalogue[i].Code < catalogue[j].Code
+	})
+
+	return catalogue
+}
+
+// WriteCatalogueJSON - writes the catalogue of declared ErrorTypes as a JSON array.
+func WriteCatalogueJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(Catalogue())
+}
+
+// WriteCatalogueMarkdown - writes the catalogue of declared ErrorTypes as a
+// Markdown table.
+func WriteCatalogueMarkdown(w io.Writer) error {
+	var builder strings.Builder
+
+	builder.WriteString("| Pkg | Code | Common Code | Severity | Public Msg | Description |\n")
+	builder.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+
+	for _, declaration := range Catalogue() {
+		commonCode := ""
+		if declaration.CommonCode != 0 {
+			commonCode = fmt.Sprint(declaration.CommonCode)
+		}
+
+		severity := ""
+		if declaration.Severity != SeverityUnspecified {
+			severity = declaration.Severity.String()
+		}
+
+		fmt.Fprintf(&builder, "| %s | %d | %s | %s | %s | %s |\n",
+			escapeMarkdown(declaration.Pkg),
+			declaration.Code,
+			commonCode,
+			severity,
+			escapeMarkdown(declaration.PublicMsg),
+			escapeMarkdown(declaration.Description),
+		)
+	}
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
+
+// escapeMarkdown - escapes characters that would break a Markdown table cell.
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
@@ -0,0 +1,75 @@
+package errortype
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twothicc/common-go/commonerror"
+)
+
+var declaredErrorTypeB2 = Declare(ErrorType{Code: 2, Pkg: "declarepkgb", Severity: SeverityCritical}, "second of b")
+var declaredErrorTypeA1 = Declare(ErrorType{
+	Code:       1,
+	Pkg:        "declarepkga",
+	CommonCode: commonerror.ErrCodeNotFound,
+	PublicMsg:  "a | not found",
+}, "first of a")
+var declaredErrorTypeB1 = Declare(ErrorType{Code: 1, Pkg: "declarepkgb"}, "first of b")
+
+func TestDeclareReturnsErrorType(t *testing.T) {
+	assert.Equal(t, ErrorType{Code: 2, Pkg: "declarepkgb", Severity: SeverityCritical}, declaredErrorTypeB2)
+	assert.True(t, declaredErrorTypeB1.Is(declaredErrorTypeB1.New("one")))
+}
+
+func TestDeclareDuplicatePanics(t *testing.T) {
+	assert.Panics(t, func() {
+		Declare(ErrorType{Code: 1, Pkg: "declarepkga"}, "duplicate")
+	})
+	assert.NotPanics(t, func() {
+		Declare(ErrorType{Code: 1, Pkg: "declarepkgc"}, "same code, different pkg")
+	})
+}
+
+func TestCatalogueOrdered(t *testing.T) {
+	var catalogue []Declaration
+
+	for _, declaration := range Catalogue() {
+		if declaration.Pkg == "declarepkga" || declaration.Pkg == "declarepkgb" {
+			catalogue = append(catalogue, declaration)
+		}
+	}
+
+	assert.Len(t, catalogue, 3)
+	assert.Equal(t, declaredErrorTypeA1.Pkg, catalogue[0].Pkg)
+	assert.Equal(t, declaredErrorTypeB1.Code, catalogue[1].Code)
+	assert.Equal(t, declaredErrorTypeB2.Code, catalogue[2].Code)
+}
+
+func TestWriteCatalogue(t *testing.T) {
+	jsonBuffer := &bytes.Buffer{}
+	assert.Nil(t, WriteCatalogueJSON(jsonBuffer))
+
+	var catalogue []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(jsonBuffer.Bytes(), &catalogue))
+	assert.Contains(t, catalogue, map[string]interface{}{
+		"pkg":         "declarepkga",
+		"code":        float64(1),
+		"commonCode":  float64(commonerror.ErrCodeNotFound),
+		"publicMsg":   "a | not found",
+		"description": "first of a",
+	})
+	assert.Contains(t, catalogue, map[string]interface{}{
+		"pkg":         "declarepkgb",
+		"code":        float64(2),
+		"severity":    "critical",
+		"description": "second of b",
+	})
+
+	markdownBuffer := &bytes.Buffer{}
+	assert.Nil(t, WriteCatalogueMarkdown(markdownBuffer))
+	assert.Contains(t, markdownBuffer.String(), "| Pkg | Code | Common Code | Severity | Public Msg | Description |\n")
+	assert.Contains(t, markdownBuffer.String(), "| declarepkga | 1 | 7 |  | a \\| not found | first of a |\n")
+	assert.Contains(t, markdownBuffer.String(), "| declarepkgb | 2 |  | critical |  | second of b |\n")
+}
@@ -3,23 +3,41 @@ package errortype
 import (
 	"errors"
 	"fmt"
+	"runtime"
 )
 
 type IError interface {
 	error
 	Msg() string
+	MsgHistory() []string
+	Fields() []Field
+	StackTrace() []runtime.Frame
 }
 
 // Error - contains ErrorType and error msg providing stack trace error.
+//
+// Errors are immutable once created, so the same Error can be shared and wrapped
+// concurrently.
 type Error struct {
+	cause  error
 	msg    string
 	detail ErrorType
+	fields []Field
+	stack  []uintptr
 }
 
 // ErrorType - contains details to differentiate between Errors.
+//
+// Errors are differentiated by Pkg and Code only. CommonCode and PublicMsg
+// optionally declare the common error code and public msg the ErrorType maps
+// to when converted by ToCommonError. Severity optionally declares the level
+// Errors are logged at.
 type ErrorType struct {
-	Pkg  string
-	Code int32
+	Pkg        string
+	PublicMsg  string
+	Code       int32
+	CommonCode int32
+	Severity   Severity
 }
 
 // Error - returns formatted string containing error details and error msg.
@@ -32,61 +50,112 @@ func (e *Error) Msg() string {
 	return e.msg
 }
 
-// New - constructor for custom Error
-func (e ErrorType) New(msg string) IError {
-	return &Error{
-		detail: e,
-		msg:    msg,
+// MsgHistory - returns the msgs of Error and of the Errors it wraps, outermost first.
+func (e *Error) MsgHistory() []string {
+	msgs := []string{e.msg}
+
+	otherErr := &Error{}
+	if e.cause != nil && errors.As(e.cause, &otherErr) {
+		msgs = append(msgs, otherErr.MsgHistory()...)
 	}
+
+	return msgs
 }
 
-// Is - checks if err is of same code and package as ErrorType
-func (e ErrorType) Is(err error) bool {
-	otherErr := &Error{}
+// Unwrap - returns the wrapped error, nil if there is none.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
 
-	if !errors.As(err, &otherErr) {
+// Is - checks if target is an Error of the same ErrorType, so that errors.Is
+// matches Errors of the same ErrorType at any depth of a chain.
+func (e *Error) Is(target error) bool {
+	targetErr, ok := target.(*Error)
+	if !ok {
 		return false
 	}
 
-	return otherErr.detail.Code == e.Code && otherErr.detail.Pkg == e.Pkg
+	return e.detail.sameType(targetErr.detail)
+}
+
+// New - constructor for custom Error, with optional fields attached
+func (e ErrorType) New(msg string, fields ...Field) IError {
+	return e.newError(msg, nil, fields)
+}
+
+// newError - creates Error wrapping cause, capturing the stack of the exported
+// constructor's caller.
+//
+// Must only be called directly by exported constructors.
+func (e ErrorType) newError(msg string, cause error, fields []Field) *Error {
+	return &Error{
+		detail: e,
+		msg:    msg,
+		cause:  cause,
+		fields: fields,
+		stack:  callers(),
+	}
+}
+
+// sameType - checks if other is the same ErrorType, ErrorTypes being
+// identified by both Pkg and Code.
+func (e ErrorType) sameType(other ErrorType) bool {
+	return e.Pkg == other.Pkg && e.Code == other.Code
 }
 
-// Wrap - if err is of same ErrorType, then no wrapping is done.
-func (e ErrorType) Wrap(err error) IError {
+// Is - checks if err, or any error it wraps, is of same code and package as ErrorType
+func (e ErrorType) Is(err error) bool {
+	return errors.Is(err, &Error{detail: e})
+}
+
+// Wrap - wraps err, keeping it in the chain for errors.Is and errors.As.
+// If err is of same ErrorType, then no wrapping is done.
+//
+// fields are accumulated with those of the wrapped Errors. If err is of same
+// ErrorType, a copy of err with fields attached is returned instead.
+func (e ErrorType) Wrap(err error, fields ...Field) IError {
 	otherErr := &Error{}
 
 	if !errors.As(err, &otherErr) {
-		return e.New(err.Error())
+		return e.newError(err.Error(), err, fields)
 	}
 
-	if e.Code == otherErr.detail.Code &&
-		e.Pkg == otherErr.detail.Pkg {
-		return otherErr
+	if e.sameType(otherErr.detail) {
+		return otherErr.withFields(fields)
 	}
 
-	return &Error{
-		detail: e,
-		msg:    fmt.Sprintf("%s | %s", otherErr.msg, otherErr.Error()),
-	}
+	return e.newError(fmt.Sprintf("%s | %s", otherErr.msg, otherErr.Error()), err, fields)
 }
 
-// WrapWithMsg - if err is of same ErrorType, err's msg is changed
-// to the provided msg.
-func (e ErrorType) WrapWithMsg(err error, msg string) IError {
+// WrapWithMsg - wraps err with msg, keeping it in the chain for errors.Is and
+// errors.As. If err is of same ErrorType, a new Error with the provided msg is
+// returned and err's msg is kept in MsgHistory, err itself is left unchanged.
+// If err is not an Error, msg is used as is and err is only kept as cause.
+//
+// fields are accumulated with those of the wrapped Errors.
+func (e ErrorType) WrapWithMsg(err error, msg string, fields ...Field) IError {
 	otherErr := &Error{}
 
 	if !errors.As(err, &otherErr) {
-		return e.New(err.Error())
+		return e.newError(msg, err, fields)
 	}
 
-	if e.Code == otherErr.detail.Code &&
-		e.Pkg == otherErr.detail.Pkg {
-		otherErr.msg = msg
-		return otherErr
+	if e.sameType(otherErr.detail) {
+		return e.newError(msg, err, fields)
 	}
 
-	return &Error{
-		detail: e,
-		msg:    fmt.Sprintf("%s | %s", msg, otherErr.Error()),
+	return e.newError(fmt.Sprintf("%s | %s", msg, otherErr.Error()), err, fields)
+}
+
+// withFields - returns a copy of Error with fields attached, or Error itself
+// if there are no fields to attach.
+func (e *Error) withFields(fields []Field) *Error {
+	if len(fields) == 0 {
+		return e
 	}
+
+	errCopy := *e
+	errCopy.fields = mergeFields(e.fields, fields)
+
+	return &errCopy
 }
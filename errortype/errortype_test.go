@@ -1,7 +1,10 @@
 package errortype
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,6 +23,15 @@ func TestSameCodeDiffPkgIsFalse(t *testing.T) {
 	assert.False(t, dummyErrorType11.Is(dummyError13))
 }
 
+func TestSameCodeDiffPkgSentinelsNotEqual(t *testing.T) {
+	sentinel1 := dummyErrorType11.New("one")
+	sentinel3 := dummyErrorType13.New("one")
+
+	assert.False(t, errors.Is(sentinel1, sentinel3))
+	assert.False(t, errors.Is(dummyErrorType22.Wrap(sentinel3), sentinel1))
+	assert.True(t, errors.Is(dummyErrorType22.Wrap(sentinel1), sentinel1))
+}
+
 func TestDiffCodeSamePkgIsFalse(t *testing.T) {
 	dummyError11 := dummyErrorType11.New("one")
 	dummyError21 := dummyErrorType21.New("one")
@@ -61,9 +73,40 @@ func TestDiffErrorTypeWrapping(t *testing.T) {
 		"error: code=2000, pkg=dummypackage2, msg=one | error: code=1000, pkg=dummypackage1, msg=one")
 }
 
-func TestSameWrappedErrorTypeIsFalse(t *testing.T) {
+func TestSameWrappedErrorTypeIsTrue(t *testing.T) {
 	dummyError := dummyErrorType11.New("one")
 	dummyErrorWrapped := dummyErrorType22.Wrap(dummyError)
 
-	assert.False(t, dummyErrorType11.Is(dummyErrorWrapped))
+	assert.True(t, dummyErrorType11.Is(dummyErrorWrapped))
+	assert.True(t, dummyErrorType22.Is(dummyErrorWrapped))
+	assert.False(t, dummyErrorType13.Is(dummyErrorWrapped))
+}
+
+func TestDeeplyWrappedErrorTypeIsTrue(t *testing.T) {
+	dummyError := dummyErrorType11.New("one")
+	dummyErrorWrapped := dummyErrorType13.Wrap(fmt.Errorf("context: %w", dummyErrorType22.Wrap(dummyError)))
+
+	assert.True(t, dummyErrorType11.Is(dummyErrorWrapped))
+	assert.True(t, dummyErrorType22.Is(dummyErrorWrapped))
+	assert.True(t, errors.Is(dummyErrorWrapped, dummyErrorType11.New("")))
+	assert.False(t, dummyErrorType21.Is(dummyErrorWrapped))
+}
+
+func TestWrapInbuiltErrorKeepsCause(t *testing.T) {
+	dummyErrorWrapped := dummyErrorType11.Wrap(io.EOF)
+	dummyErrorWrappedWithMsg := dummyErrorType22.WrapWithMsg(fmt.Errorf("read: %w", context.Canceled), "two")
+
+	assert.True(t, errors.Is(dummyErrorWrapped, io.EOF))
+	assert.True(t, errors.Is(dummyErrorWrappedWithMsg, context.Canceled))
+	assert.Equal(t, "error: code=1000, pkg=dummypackage1, msg=EOF", dummyErrorWrapped.Error())
+	assert.Equal(t, "error: code=2000, pkg=dummypackage2, msg=two", dummyErrorWrappedWithMsg.Error())
+}
+
+func TestWrapKeepsPrintedFormat(t *testing.T) {
+	dummyError := dummyErrorType11.New("one")
+	dummyErrorWrapped := dummyErrorType22.WrapWithMsg(dummyError, "two")
+
+	assert.Equal(t, dummyError, errors.Unwrap(dummyErrorWrapped))
+	assert.Equal(t, dummyErrorWrapped.Error(),
+		"error: code=2000, pkg=dummypackage2, msg=two | error: code=1000, pkg=dummypackage1, msg=one")
 }
@@ -0,0 +1,88 @@
+package errortype
+
+import (
+	"errors"
+	"time"
+)
+
+// Field - typed key/value context attached to an Error for debugging.
+type Field struct {
+	Value interface{}
+	Key   string
+}
+
+// String - constructs a Field with a string value.
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int - constructs a Field with an int value.
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int64 - constructs a Field with an int64 value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool - constructs a Field with a bool value.
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration - constructs a Field with a time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Any - constructs a Field with an arbitrary value.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Fields - returns fields accumulated across the chain of Errors, from the
+// innermost to the outermost Error.
+//
+// If a key is attached more than once, the outermost value is kept at the
+// position the key first appeared.
+func (e *Error) Fields() []Field {
+	var fields []Field
+
+	otherErr := &Error{}
+	if e.cause != nil && errors.As(e.cause, &otherErr) {
+		fields = otherErr.Fields()
+	}
+
+	if len(e.fields) == 0 {
+		return fields
+	}
+
+	return mergeFields(fields, e.fields)
+}
+
+// mergeFields - appends fields to existingFields, overwriting the value of
+// fields with the same key.
+func mergeFields(existingFields, fields []Field) []Field {
+	mergedFields := make([]Field, len(existingFields), len(existingFields)+len(fields))
+	copy(mergedFields, existingFields)
+
+	for _, field := range fields {
+		overwritten := false
+
+		for i := range mergedFields {
+			if mergedFields[i].Key == field.Key {
+				mergedFields[i].Value = field.Value
+				overwritten = true
+
+				break
+			}
+		}
+
+		if !overwritten {
+			mergedFields = append(mergedFields, field)
+		}
+	}
+
+	return mergedFields
+}
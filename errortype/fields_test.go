@@ -0,0 +1,39 @@
+package errortype
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithFields(t *testing.T) {
+	dummyError := dummyErrorType11.New("one", String("userId", "u1"), Int("attempt", 2))
+
+	assert.Equal(t, []Field{String("userId", "u1"), Int("attempt", 2)}, dummyError.Fields())
+}
+
+func TestFieldsAccumulateAcrossWrap(t *testing.T) {
+	dummyError := dummyErrorType11.New("one", String("userId", "u1"), Int("attempt", 1))
+	dummyErrorWrapped := dummyErrorType22.Wrap(fmt.Errorf("context: %w", dummyError), String("orderId", "o1"))
+	dummyErrorWrappedWithMsg := dummyErrorType13.WrapWithMsg(dummyErrorWrapped, "three", Int("attempt", 3))
+
+	assert.Equal(t, []Field{
+		String("userId", "u1"),
+		Int("attempt", 3),
+		String("orderId", "o1"),
+	}, dummyErrorWrappedWithMsg.Fields())
+}
+
+func TestSameErrorTypeWrapWithFieldsCopies(t *testing.T) {
+	dummyError := dummyErrorType11.New("one", String("userId", "u1"))
+	dummyErrorWrapped := dummyErrorType11.Wrap(dummyError, String("orderId", "o1"))
+
+	assert.Equal(t, dummyError.Error(), dummyErrorWrapped.Error())
+	assert.Equal(t, []Field{String("userId", "u1")}, dummyError.Fields())
+	assert.Equal(t, []Field{String("userId", "u1"), String("orderId", "o1")}, dummyErrorWrapped.Fields())
+}
+
+func TestNoFields(t *testing.T) {
+	assert.Empty(t, dummyErrorType11.New("one").Fields())
+}
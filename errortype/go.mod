@@ -1,11 +1,20 @@
 module github.com/twothicc/common-go/errortype
 
-go 1.18
+go 1.20
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013 // indirect
+	google.golang.org/protobuf v1.27.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-require github.com/stretchr/testify v1.8.0
+require (
+	github.com/stretchr/testify v1.8.0
+	github.com/twothicc/common-go/commonerror v0.0.0-20220815084053-2bc49f4b1954
+	google.golang.org/grpc v1.48.0
+)
+
+replace github.com/twothicc/common-go/commonerror => ../commonerror
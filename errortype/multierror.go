@@ -0,0 +1,141 @@
+package errortype
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/twothicc/common-go/commonerror"
+)
+
+// MultiError - aggregates the Errors of multiple items, e.g. of a batch request.
+//
+// errors.Is and errors.As match against any of the aggregated Errors.
+type MultiError struct {
+	items []*ItemError
+	mu    sync.RWMutex
+}
+
+// ItemError - Error of a single item, identified by its index, or by its key
+// if the key is not empty.
+type ItemError struct {
+	Err   IError
+	Key   string
+	Index int
+}
+
+// id - returns the identifier of the item used in messages and details.
+func (ie *ItemError) id() string {
+	if ie.Key != "" {
+		return ie.Key
+	}
+
+	return strconv.Itoa(ie.Index)
+}
+
+// NewMultiError - constructor for MultiError
+func NewMultiError() *MultiError {
+	return &MultiError{}
+}
+
+// Add - adds the Error of the item at index. Nil errors are ignored.
+func (m *MultiError) Add(index int, err IError) {
+	m.add(&ItemError{Index: index, Err: err})
+}
+
+// AddWithKey - adds the Error of the item identified by key. Nil errors are ignored.
+func (m *MultiError) AddWithKey(key string, err IError) {
+	m.add(&ItemError{Key: key, Err: err})
+}
+
+func (m *MultiError) add(item *ItemError) {
+	if item.Err == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items = append(m.items, item)
+}
+
+// Len - returns the number of aggregated Errors.
+func (m *MultiError) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.items)
+}
+
+// Items - returns the aggregated Errors ordered by index, followed by those
+// identified by key ordered by key.
+func (m *MultiError) Items() []*ItemError {
+	m.mu.RLock()
+	items := make([]*ItemError, len(m.items))
+	copy(items, m.items)
+	m.mu.RUnlock()
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if (items[i].Key == "") != (items[j].Key == "") {
+			return items[i].Key == ""
+		}
+
+		if items[i].Key == "" {
+			return items[i].Index < items[j].Index
+		}
+
+		return items[i].Key < items[j].Key
+	})
+
+	return items
+}
+
+// ErrorOrNil - returns MultiError if it aggregates any Errors, otherwise nil.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || m.Len() == 0 {
+		return nil
+	}
+
+	return m
+}
+
+// Error - returns the count of aggregated Errors followed by each Error
+// prefixed by its item, in the order of Items.
+func (m *MultiError) Error() string {
+	items := m.Items()
+	itemMsgs := make([]string, 0, len(items)+1)
+	itemMsgs = append(itemMsgs, fmt.Sprintf(MultiErrFormat, len(items)))
+
+	for _, item := range items {
+		itemMsgs = append(itemMsgs, fmt.Sprintf(ItemErrFormat, item.id(), item.Err.Error()))
+	}
+
+	return strings.Join(itemMsgs, " | ")
+}
+
+// Unwrap - returns the aggregated Errors, in the order of Items.
+func (m *MultiError) Unwrap() []error {
+	items := m.Items()
+	errs := make([]error, 0, len(items))
+
+	for _, item := range items {
+		errs = append(errs, item.Err)
+	}
+
+	return errs
+}
+
+// ToCommonError - converts MultiError into a common error of code and msg, with a
+// field violation per aggregated Error, with the item as field and the public msg
+// of the Error as description, as converted by errortype.ToCommonError.
+func (m *MultiError) ToCommonError(code int32, msg string) commonerror.ICommonError {
+	details := &commonerror.Details{}
+
+	if commonError := m.toCommonError(false); commonError != nil {
+		details.FieldViolations = commonError.Details().FieldViolations
+	}
+
+	return commonerror.NewWithDetails(code, msg, details)
+}
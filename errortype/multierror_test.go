@@ -0,0 +1,73 @@
+package errortype
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twothicc/common-go/commonerror"
+)
+
+func TestMultiErrorDeterministicError(t *testing.T) {
+	multiErr := NewMultiError()
+	multiErr.AddWithKey("b", dummyErrorType22.New("two"))
+	multiErr.Add(3, dummyErrorType11.New("three"))
+	multiErr.AddWithKey("a", dummyErrorType13.New("one"))
+	multiErr.Add(1, dummyErrorType11.New("one"))
+
+	assert.Equal(t, 4, multiErr.Len())
+	assert.Equal(t, "multi error: count=4"+
+		" | [1] error: code=1000, pkg=dummypackage1, msg=one"+
+		" | [3] error: code=1000, pkg=dummypackage1, msg=three"+
+		" | [a] error: code=1000, pkg=dummypackage3, msg=one"+
+		" | [b] error: code=2000, pkg=dummypackage2, msg=two", multiErr.Error())
+}
+
+func TestMultiErrorIsAndAsAnyMember(t *testing.T) {
+	multiErr := NewMultiError()
+	multiErr.Add(0, dummyErrorType11.New("one"))
+	multiErr.Add(1, dummyErrorType22.Wrap(io.EOF))
+
+	err := multiErr.ErrorOrNil()
+
+	assert.True(t, dummyErrorType11.Is(err))
+	assert.True(t, dummyErrorType22.Is(err))
+	assert.False(t, dummyErrorType13.Is(err))
+	assert.True(t, errors.Is(err, io.EOF))
+
+	var target *MultiError
+	assert.True(t, errors.As(err, &target))
+}
+
+func TestMultiErrorEmptyErrorOrNil(t *testing.T) {
+	multiErr := NewMultiError()
+	multiErr.Add(0, nil)
+
+	assert.Nil(t, multiErr.ErrorOrNil())
+}
+
+func TestMultiErrorToCommonError(t *testing.T) {
+	multiErr := NewMultiError()
+	multiErr.AddWithKey("email", mappedErrorTypeInvalid.New("email has no @"))
+	multiErr.Add(0, dummyErrorType11.New("missing name"))
+
+	commonError := multiErr.ToCommonError(commonerror.ErrCodeInvalidArgument, commonerror.ErrMsgInvalidArgument)
+
+	assert.Equal(t, int32(commonerror.ErrCodeInvalidArgument), commonError.Code())
+	assert.Equal(t, commonerror.ErrMsgInvalidArgument, commonError.Msg())
+	assert.Equal(t, []*commonerror.FieldViolation{
+		{Field: "0", Description: commonerror.ErrMsgServer},
+		{Field: "email", Description: commonerror.ErrMsgInvalidArgument},
+	}, commonError.Details().FieldViolations)
+}
+
+func TestMultiErrorToCommonErrorHidesInternals(t *testing.T) {
+	multiErr := NewMultiError()
+	multiErr.Add(0, dummyErrorType11.New("db password rejected"))
+
+	commonError := multiErr.ToCommonError(commonerror.ErrCodeInvalidArgument, commonerror.ErrMsgInvalidArgument)
+
+	assert.NotContains(t, commonError.Details().FieldViolations[0].Description, "db password")
+	assert.Equal(t, ToCommonError(multiErr).Details().FieldViolations, commonError.Details().FieldViolations)
+}
@@ -0,0 +1,110 @@
+package errortype
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const concurrentWrappers = 50
+
+// wrapConcurrently - calls wrap from concurrentWrappers goroutines and returns
+// the results ordered by goroutine.
+func wrapConcurrently(wrap func(i int) IError) []IError {
+	results := make([]IError, concurrentWrappers)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrentWrappers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			results[i] = wrap(i)
+		}(i)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func TestSameErrorTypeWrapWithMsgDoesNotMutate(t *testing.T) {
+	sentinel := dummyErrorType11.New("one", String("userId", "u1"))
+	dummyErrorWrapped := dummyErrorType11.WrapWithMsg(sentinel, "two", String("orderId", "o1"))
+
+	assert.Equal(t, "error: code=1000, pkg=dummypackage1, msg=one", sentinel.Error())
+	assert.Equal(t, []Field{String("userId", "u1")}, sentinel.Fields())
+	assert.Equal(t, "error: code=1000, pkg=dummypackage1, msg=two", dummyErrorWrapped.Error())
+	assert.Equal(t, []Field{String("userId", "u1"), String("orderId", "o1")}, dummyErrorWrapped.Fields())
+	assert.Equal(t, sentinel, dummyErrorWrapped.(*Error).Unwrap())
+}
+
+func TestWrapWithMsgKeepsMsgHistory(t *testing.T) {
+	dummyError := dummyErrorType11.New("one")
+	dummyErrorWrapped := dummyErrorType11.WrapWithMsg(fmt.Errorf("context: %w", dummyError), "two")
+	dummyErrorWrapped = dummyErrorType22.WrapWithMsg(dummyErrorWrapped, "three")
+	dummyErrorWrapped = dummyErrorType22.WrapWithMsg(dummyErrorWrapped, "four")
+
+	assert.Equal(t, []string{"four", "three | error: code=1000, pkg=dummypackage1, msg=two", "two", "one"},
+		dummyErrorWrapped.MsgHistory())
+	assert.True(t, dummyErrorType11.Is(dummyErrorWrapped))
+}
+
+func TestConcurrentWrapWithMsgOfSharedError(t *testing.T) {
+	sentinel := dummyErrorType11.New("one", String("userId", "u1"))
+
+	results := wrapConcurrently(func(i int) IError {
+		return dummyErrorType11.WrapWithMsg(sentinel, fmt.Sprintf("msg %d", i), Int("attempt", i))
+	})
+
+	for i, result := range results {
+		assert.Equal(t, fmt.Sprintf("msg %d", i), result.Msg())
+		assert.Equal(t, []string{fmt.Sprintf("msg %d", i), "one"}, result.MsgHistory())
+		assert.Equal(t, []Field{String("userId", "u1"), Int("attempt", i)}, result.Fields())
+	}
+
+	assert.Equal(t, "one", sentinel.Msg())
+	assert.Equal(t, []Field{String("userId", "u1")}, sentinel.Fields())
+}
+
+func TestConcurrentWrapOfSharedError(t *testing.T) {
+	sentinel := dummyErrorType11.New("one", String("userId", "u1"))
+
+	results := wrapConcurrently(func(i int) IError {
+		if i%2 == 0 {
+			return dummyErrorType11.Wrap(sentinel, Int("attempt", i))
+		}
+
+		return dummyErrorType22.Wrap(sentinel, Int("attempt", i))
+	})
+
+	for i, result := range results {
+		assert.Equal(t, []Field{String("userId", "u1"), Int("attempt", i)}, result.Fields())
+		assert.True(t, dummyErrorType11.Is(result))
+	}
+
+	assert.Equal(t, []Field{String("userId", "u1")}, sentinel.Fields())
+}
+
+func TestConcurrentReadOfSharedError(t *testing.T) {
+	sentinel := dummyErrorType22.WrapWithMsg(dummyErrorType11.New("one", String("userId", "u1")), "two")
+
+	results := wrapConcurrently(func(i int) IError {
+		_ = sentinel.Error()
+		_ = sentinel.MsgHistory()
+		_ = sentinel.Fields()
+		_ = sentinel.StackTrace()
+		_ = fmt.Sprintf("%+v", sentinel)
+		_ = ToCommonError(sentinel)
+
+		return sentinel
+	})
+
+	for _, result := range results {
+		assert.Equal(t, sentinel, result)
+	}
+}
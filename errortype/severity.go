@@ -0,0 +1,59 @@
+package errortype
+
+// Severity - how severe Errors of an ErrorType are, driving the level they are
+// logged at and whether they should alert.
+type Severity int32
+
+const (
+	SeverityUnspecified Severity = iota // treated as SeverityError
+	SeverityInfo                        // expected errors, e.g. validation failures
+	SeverityWarn                        // unexpected but tolerable errors
+	SeverityError                       // errors requiring investigation
+	SeverityCritical                    // errors that should page, e.g. dependency outages
+)
+
+var severityNames = map[Severity]string{
+	SeverityUnspecified: "unspecified",
+	SeverityInfo:        "info",
+	SeverityWarn:        "warn",
+	SeverityError:       "error",
+	SeverityCritical:    "critical",
+}
+
+// String - returns the name of Severity.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+
+	return severityNames[SeverityUnspecified]
+}
+
+// MarshalText - encodes Severity as its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Severity - returns the Severity of Error's ErrorType.
+func (e *Error) Severity() Severity {
+	return e.detail.Severity
+}
+
+// SeverityOf - returns the Severity of the outermost Error in err's chain with a
+// specified Severity, SeverityUnspecified if there is none.
+func SeverityOf(err error) Severity {
+	severity := SeverityUnspecified
+
+	walkChain(err, func(chainErr error) bool {
+		otherErr, ok := chainErr.(*Error)
+		if !ok {
+			return false
+		}
+
+		severity = otherErr.detail.Severity
+
+		return severity != SeverityUnspecified
+	})
+
+	return severity
+}
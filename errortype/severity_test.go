@@ -0,0 +1,41 @@
+package errortype
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var dummyErrorTypeInfo = ErrorType{Code: 3000, Pkg: "dummypackage1", Severity: SeverityInfo}
+var dummyErrorTypeCritical = ErrorType{Code: 4000, Pkg: "dummypackage1", Severity: SeverityCritical}
+
+func TestSeverityString(t *testing.T) {
+	assert.Equal(t, "info", SeverityInfo.String())
+	assert.Equal(t, "critical", SeverityCritical.String())
+	assert.Equal(t, "unspecified", Severity(100).String())
+}
+
+func TestSeverityOfOutermostSpecified(t *testing.T) {
+	dummyError := dummyErrorTypeCritical.New("one")
+	dummyErrorWrapped := dummyErrorTypeInfo.Wrap(fmt.Errorf("context: %w", dummyError))
+
+	assert.Equal(t, SeverityCritical, dummyError.(*Error).Severity())
+	assert.Equal(t, SeverityInfo, SeverityOf(dummyErrorWrapped))
+	assert.Equal(t, SeverityCritical, SeverityOf(dummyErrorType22.Wrap(dummyError)))
+}
+
+func TestSeverityOfUnspecified(t *testing.T) {
+	assert.Equal(t, SeverityUnspecified, SeverityOf(dummyErrorType11.New("one")))
+	assert.Equal(t, SeverityUnspecified, SeverityOf(errors.New("one")))
+	assert.Equal(t, SeverityUnspecified, SeverityOf(nil))
+}
+
+func TestSeverityOfMultiError(t *testing.T) {
+	multiErr := NewMultiError()
+	multiErr.Add(0, dummyErrorType11.New("one"))
+	multiErr.Add(1, dummyErrorTypeInfo.New("two"))
+
+	assert.Equal(t, SeverityInfo, SeverityOf(multiErr))
+}
@@ -0,0 +1,84 @@
+package errortype
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync/atomic"
+)
+
+// stackTraceDisabled - non-zero if stack traces should not be captured.
+var stackTraceDisabled int32
+
+// SetStackTraceEnabled - enables or disables capturing of stack traces when
+// Errors are created. Capturing is enabled by default.
+//
+// Disabling avoids the cost of runtime.Callers on hot paths.
+func SetStackTraceEnabled(enabled bool) {
+	var disabled int32
+	if !enabled {
+		disabled = 1
+	}
+
+	atomic.StoreInt32(&stackTraceDisabled, disabled)
+}
+
+// callers - captures the stack of the caller of an exported Error constructor.
+//
+// Must only be called by newError.
+func callers() []uintptr {
+	if atomic.LoadInt32(&stackTraceDisabled) != 0 {
+		return nil
+	}
+
+	var pcs [MaxStackDepth]uintptr
+
+	// skip runtime.Callers, callers, newError and the exported constructor
+	n := runtime.Callers(4, pcs[:])
+
+	return pcs[:n]
+}
+
+// StackTrace - returns the frames of the stack captured when Error was created,
+// nil if capturing was disabled.
+func (e *Error) StackTrace() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(e.stack)
+	stackTrace := make([]runtime.Frame, 0, len(e.stack))
+
+	for {
+		frame, more := frames.Next()
+		stackTrace = append(stackTrace, frame)
+
+		if !more {
+			break
+		}
+	}
+
+	return stackTrace
+}
+
+// Format - formats Error for fmt.
+//
+// %s and %v print Error(), %+v additionally prints the stack trace, one frame
+// per function and file:line pair.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		_, _ = io.WriteString(s, e.Error())
+
+		if s.Flag('+') {
+			for _, frame := range e.StackTrace() {
+				_, _ = fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+		}
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	default:
+	}
+}
@@ -0,0 +1,44 @@
+package errortype
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCapturesCallerStack(t *testing.T) {
+	dummyError := dummyErrorType11.New("one")
+	stackTrace := dummyError.StackTrace()
+
+	assert.NotEmpty(t, stackTrace)
+	assert.True(t, strings.HasSuffix(stackTrace[0].Function, "TestNewCapturesCallerStack"))
+}
+
+func TestWrapCapturesCallerStack(t *testing.T) {
+	dummyErrorWrapped := dummyErrorType22.Wrap(dummyErrorType11.New("one"))
+	dummyErrorWrappedWithMsg := dummyErrorType22.WrapWithMsg(fmt.Errorf("one"), "two")
+
+	assert.True(t, strings.HasSuffix(dummyErrorWrapped.StackTrace()[0].Function, "TestWrapCapturesCallerStack"))
+	assert.True(t, strings.HasSuffix(dummyErrorWrappedWithMsg.StackTrace()[0].Function, "TestWrapCapturesCallerStack"))
+}
+
+func TestFormatWithStackTrace(t *testing.T) {
+	dummyError := dummyErrorType11.New("one")
+
+	assert.Equal(t, dummyError.Error(), fmt.Sprintf("%v", dummyError))
+	assert.Equal(t, dummyError.Error(), fmt.Sprintf("%s", dummyError))
+	assert.True(t, strings.HasPrefix(fmt.Sprintf("%+v", dummyError), dummyError.Error()+"\n"))
+	assert.Contains(t, fmt.Sprintf("%+v", dummyError), "TestFormatWithStackTrace")
+}
+
+func TestStackTraceDisabled(t *testing.T) {
+	SetStackTraceEnabled(false)
+	defer SetStackTraceEnabled(true)
+
+	dummyError := dummyErrorType11.New("one")
+
+	assert.Nil(t, dummyError.StackTrace())
+	assert.Equal(t, dummyError.Error(), fmt.Sprintf("%+v", dummyError))
+}
@@ -7,10 +7,19 @@ import (
 )
 
 type clientConfigs struct {
-	defaultConnConfigs *pool.ConnConfigs
-	serviceName        string
-	poolCreators       []pool.PoolCreatorFunc
-	isTest             bool
+	defaultConnConfigs  *pool.ConnConfigs
+	retryPolicy         *RetryPolicy
+	serverRetryPolicies map[string]*RetryPolicy
+	methodRetryPolicies map[string]*RetryPolicy
+	hedgingPolicies     map[string]*HedgingPolicy
+	serverTimeouts      map[string]time.Duration
+	methodTimeouts      map[string]time.Duration
+	serviceName         string
+	poolCreators        []pool.PoolCreatorFunc
+	forwardedMDKeys     []string
+	timeout             time.Duration
+	deadlineHeadroom    time.Duration
+	isTest              bool
 }
 
 func GetClientConfigs(
@@ -29,7 +38,16 @@ func GetClientConfigs(
 			init, capacity,
 			enableTLS,
 		),
-		poolCreators: poolCreators,
+		poolCreators:        poolCreators,
+		retryPolicy:         GetDefaultRetryPolicy(),
+		serverRetryPolicies: make(map[string]*RetryPolicy),
+		methodRetryPolicies: make(map[string]*RetryPolicy),
+		hedgingPolicies:     make(map[string]*HedgingPolicy),
+		serverTimeouts:      make(map[string]time.Duration),
+		methodTimeouts:      make(map[string]time.Duration),
+		timeout:             DEFAULT_TIMEOUT,
+		deadlineHeadroom:    DEFAULT_DEADLINE_HEADROOM,
+		forwardedMDKeys:     DEFAULT_FORWARDED_METADATA_KEYS,
 	}
 }
 
@@ -39,9 +57,158 @@ func GetDefaultClientConfigs(
 	poolCreators ...pool.PoolCreatorFunc,
 ) *clientConfigs {
 	return &clientConfigs{
-		serviceName:        serviceName,
-		isTest:             isTest,
-		defaultConnConfigs: pool.GetDefaultConnConfigs(),
-		poolCreators:       poolCreators,
+		serviceName:         serviceName,
+		isTest:              isTest,
+		defaultConnConfigs:  pool.GetDefaultConnConfigs(),
+		poolCreators:        poolCreators,
+		retryPolicy:         GetDefaultRetryPolicy(),
+		serverRetryPolicies: make(map[string]*RetryPolicy),
+		methodRetryPolicies: make(map[string]*RetryPolicy),
+		hedgingPolicies:     make(map[string]*HedgingPolicy),
+		serverTimeouts:      make(map[string]time.Duration),
+		methodTimeouts:      make(map[string]time.Duration),
+		timeout:             DEFAULT_TIMEOUT,
+		deadlineHeadroom:    DEFAULT_DEADLINE_HEADROOM,
+		forwardedMDKeys:     DEFAULT_FORWARDED_METADATA_KEYS,
 	}
 }
+
+// SetBreakerConfigs - sets the circuit breaker configs of servers without
+// connection pool configs of their own, nil to disable their circuit breakers.
+func (c *clientConfigs) SetBreakerConfigs(breakerConfigs *pool.BreakerConfigs) *clientConfigs {
+	c.defaultConnConfigs.Breaker = breakerConfigs
+
+	return c
+}
+
+// SetMaxAttempts - sets the max number of attempts of a call, including the first,
+// of the default retry policy.
+//
+// Retries are only made for retryable common errors.
+func (c *clientConfigs) SetMaxAttempts(maxAttempts int) *clientConfigs {
+	if c.retryPolicy == nil {
+		c.retryPolicy = GetDefaultRetryPolicy()
+	}
+
+	c.retryPolicy.MaxAttempts = maxAttempts
+
+	return c
+}
+
+// SetRetryPolicy - sets the default retry policy of calls, nil for
+// GetDefaultRetryPolicy.
+func (c *clientConfigs) SetRetryPolicy(policy *RetryPolicy) *clientConfigs {
+	c.retryPolicy = policy
+
+	return c
+}
+
+// SetServerRetryPolicy - sets the retry policy of calls to server, taking
+// precedence over the default retry policy, nil to fall back to it.
+func (c *clientConfigs) SetServerRetryPolicy(server string, policy *RetryPolicy) *clientConfigs {
+	c.serverRetryPolicies[server] = policy
+
+	return c
+}
+
+// SetMethodRetryPolicy - sets the retry policy of calls to fullMethod, taking
+// precedence over the retry policies of servers, nil to fall back to them.
+func (c *clientConfigs) SetMethodRetryPolicy(fullMethod string, policy *RetryPolicy) *clientConfigs {
+	c.methodRetryPolicies[fullMethod] = policy
+
+	return c
+}
+
+// getRetryPolicy - returns the retry policy of calls to fullMethod on server.
+func (c *clientConfigs) getRetryPolicy(server, fullMethod string) *RetryPolicy {
+	if policy := c.methodRetryPolicies[fullMethod]; policy != nil {
+		return policy
+	}
+
+	if policy := c.serverRetryPolicies[server]; policy != nil {
+		return policy
+	}
+
+	if c.retryPolicy != nil {
+		return c.retryPolicy
+	}
+
+	return GetDefaultRetryPolicy()
+}
+
+// SetHedgingPolicy - marks fullMethod as idempotent and sets the policy of hedged
+// attempts of its calls, nil to disable hedging.
+//
+// Only idempotent methods should be hedged, as a call may be handled by more than
+// one server.
+func (c *clientConfigs) SetHedgingPolicy(fullMethod string, policy *HedgingPolicy) *clientConfigs {
+	c.hedgingPolicies[fullMethod] = policy
+
+	return c
+}
+
+// getHedgingPolicy - returns the hedging policy of calls to fullMethod, nil if
+// they are not hedged.
+func (c *clientConfigs) getHedgingPolicy(fullMethod string) *HedgingPolicy {
+	policy := c.hedgingPolicies[fullMethod]
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return nil
+	}
+
+	return policy
+}
+
+// SetTimeout - sets the default timeout of calls, 0 for no timeout other than
+// the ctx deadline.
+func (c *clientConfigs) SetTimeout(timeout time.Duration) *clientConfigs {
+	c.timeout = timeout
+
+	return c
+}
+
+// SetServerTimeout - sets the timeout of calls to server, taking precedence over
+// the default timeout.
+func (c *clientConfigs) SetServerTimeout(server string, timeout time.Duration) *clientConfigs {
+	c.serverTimeouts[server] = timeout
+
+	return c
+}
+
+// SetMethodTimeout - sets the timeout of calls to fullMethod, taking precedence
+// over the timeouts of servers.
+func (c *clientConfigs) SetMethodTimeout(fullMethod string, timeout time.Duration) *clientConfigs {
+	c.methodTimeouts[fullMethod] = timeout
+
+	return c
+}
+
+// SetDeadlineHeadroom - sets the time reserved for the caller before the ctx
+// deadline, e.g. to handle the result of calls.
+func (c *clientConfigs) SetDeadlineHeadroom(headroom time.Duration) *clientConfigs {
+	c.deadlineHeadroom = headroom
+
+	return c
+}
+
+// SetForwardedMetadataKeys - sets the incoming metadata keys forwarded to outgoing
+// metadata of calls, replacing DEFAULT_FORWARDED_METADATA_KEYS.
+//
+// The request id is always forwarded.
+func (c *clientConfigs) SetForwardedMetadataKeys(keys ...string) *clientConfigs {
+	c.forwardedMDKeys = keys
+
+	return c
+}
+
+// getTimeout - returns the timeout of calls to fullMethod on server.
+func (c *clientConfigs) getTimeout(server, fullMethod string) time.Duration {
+	if timeout, ok := c.methodTimeouts[fullMethod]; ok {
+		return timeout
+	}
+
+	if timeout, ok := c.serverTimeouts[server]; ok {
+		return timeout
+	}
+
+	return c.timeout
+}
@@ -0,0 +1,45 @@
+package grpcclient
+
+import "time"
+
+const (
+	DEFAULT_MAX_ATTEMPTS       = 1
+	DEFAULT_INITIAL_BACKOFF    = 100 * time.Millisecond
+	DEFAULT_MAX_BACKOFF        = 5 * time.Second
+	DEFAULT_BACKOFF_MULTIPLIER = 2
+	DEFAULT_BACKOFF_JITTER     = 0.2
+)
+
+const (
+	DEFAULT_TIMEOUT           = 10 * time.Second
+	DEFAULT_DEADLINE_HEADROOM = 10 * time.Millisecond
+)
+
+// DEFAULT_FORWARDED_METADATA_KEYS - incoming metadata keys forwarded to outgoing
+// metadata by default.
+var DEFAULT_FORWARDED_METADATA_KEYS = []string{
+	"x-request-id",
+	"x-tenant-id",
+	"x-user-id",
+	"x-locale",
+	"accept-language",
+}
+
+const (
+	DEFAULT_HEDGING_DELAY            = 50 * time.Millisecond
+	DEFAULT_HEDGING_DELAY_PERCENTILE = 0.95
+	DEFAULT_HEDGING_MAX_ATTEMPTS     = 2
+	HEDGING_LATENCY_SAMPLES          = 100 // latest latencies of each method kept for percentiles
+	HEDGING_MIN_LATENCY_SAMPLES      = 20  // latencies of a method needed for percentiles
+)
+
+const (
+	ATTEMPT_TAG         = "grpc.attempt"
+	HEDGE_TAG           = "grpc.hedge"
+	HEDGED_ATTEMPTS_TAG = "grpc.hedged_attempts"
+)
+
+const (
+	// DEADLINE_BUDGET_METADATA_KEY - same as the key read by grpcserver.
+	DEADLINE_BUDGET_METADATA_KEY = "x-deadline-budget-ms"
+)
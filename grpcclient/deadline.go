@@ -0,0 +1,91 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// withDeadlineBudget - returns ctx bounded by the timeout of fullMethod on server,
+// and by the deadline of ctx less the headroom reserved for the caller.
+//
+// Returns a common error of ErrCodeTimeout if the deadline of ctx leaves no budget
+// beyond the headroom.
+func (c *clientConfigs) withDeadlineBudget(
+	ctx context.Context,
+	server, fullMethod string,
+) (context.Context, context.CancelFunc, commonerror.ICommonError) {
+	timeout := c.getTimeout(server, fullMethod)
+
+	if deadline, ok := ctx.Deadline(); ok {
+		budget := time.Until(deadline) - c.deadlineHeadroom
+		if budget <= 0 {
+			return nil, nil, commonerror.New(
+				commonerror.ErrCodeTimeout,
+				fmt.Sprintf("insufficient deadline budget, server = %s, method = %s", server, fullMethod),
+			)
+		}
+
+		if timeout <= 0 || budget < timeout {
+			timeout = budget
+		}
+	}
+
+	if timeout <= 0 {
+		return ctx, func() {}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctx, cancel, nil
+}
+
+// UnaryClientDeadlineInterceptor - propagates the remaining deadline budget of
+// calls in outgoing metadata, in milliseconds.
+func UnaryClientDeadlineInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(withBudgetMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientDeadlineInterceptor - propagates the remaining deadline budget of
+// streams in outgoing metadata, in milliseconds.
+func StreamClientDeadlineInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(withBudgetMetadata(ctx), desc, cc, method, opts...)
+	}
+}
+
+// withBudgetMetadata - sets the remaining budget until the deadline of ctx, if
+// any, in outgoing metadata.
+func withBudgetMetadata(ctx context.Context) context.Context {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return ctx
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(DEADLINE_BUDGET_METADATA_KEY, strconv.FormatInt(time.Until(deadline).Milliseconds(), 10))
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
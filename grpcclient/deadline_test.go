@@ -0,0 +1,178 @@
+package grpcclient
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGetTimeout(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true)
+	assert.Equal(t, DEFAULT_TIMEOUT, configs.getTimeout(testServer, testMethod))
+
+	configs.SetTimeout(time.Second)
+	assert.Equal(t, time.Second, configs.getTimeout(testServer, testMethod))
+
+	configs.SetServerTimeout(testServer, 2*time.Second)
+	assert.Equal(t, 2*time.Second, configs.getTimeout(testServer, testMethod))
+	assert.Equal(t, time.Second, configs.getTimeout("other:8080", testMethod))
+
+	configs.SetMethodTimeout(testMethod, 3*time.Second)
+	assert.Equal(t, 3*time.Second, configs.getTimeout(testServer, testMethod))
+	assert.Equal(t, 2*time.Second, configs.getTimeout(testServer, "/test.Svc/List"))
+
+	configs.SetMethodTimeout(testMethod, 0)
+	assert.Equal(t, time.Duration(0), configs.getTimeout(testServer, testMethod))
+}
+
+func TestWithDeadlineBudgetTimeout(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true).
+		SetServerTimeout(testServer, 2*time.Second).
+		SetMethodTimeout(testMethod, time.Second)
+
+	tests := []struct {
+		name       string
+		server     string
+		fullMethod string
+		timeout    time.Duration
+	}{
+		{name: "method timeout", server: testServer, fullMethod: testMethod, timeout: time.Second},
+		{name: "server timeout", server: testServer, fullMethod: "/test.Svc/List", timeout: 2 * time.Second},
+		{name: "default timeout", server: "other:8080", fullMethod: "/test.Svc/List", timeout: DEFAULT_TIMEOUT},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now()
+
+			ctx, cancel, commonErr := configs.withDeadlineBudget(context.Background(), test.server, test.fullMethod)
+			require.Nil(t, commonErr)
+
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.WithinDuration(t, start.Add(test.timeout), deadline, 50*time.Millisecond)
+		})
+	}
+}
+
+func TestWithDeadlineBudgetHeadroom(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true).SetDeadlineHeadroom(100 * time.Millisecond)
+
+	parentCtx, parentCancel := context.WithTimeout(context.Background(), time.Second)
+	defer parentCancel()
+
+	parentDeadline, _ := parentCtx.Deadline()
+
+	ctx, cancel, commonErr := configs.withDeadlineBudget(parentCtx, testServer, testMethod)
+	require.Nil(t, commonErr)
+
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, parentDeadline.Add(-100*time.Millisecond), deadline, 20*time.Millisecond)
+
+	configs.SetTimeout(200 * time.Millisecond)
+
+	start := time.Now()
+
+	ctx, cancel, commonErr = configs.withDeadlineBudget(parentCtx, testServer, testMethod)
+	require.Nil(t, commonErr)
+
+	defer cancel()
+
+	deadline, _ = ctx.Deadline()
+	assert.WithinDuration(t, start.Add(200*time.Millisecond), deadline, 20*time.Millisecond)
+}
+
+func TestWithDeadlineBudgetInsufficient(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true).SetDeadlineHeadroom(100 * time.Millisecond)
+
+	parentCtx, parentCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer parentCancel()
+
+	ctx, cancel, commonErr := configs.withDeadlineBudget(parentCtx, testServer, testMethod)
+	require.NotNil(t, commonErr)
+	assert.Equal(t, int32(commonerror.ErrCodeTimeout), commonErr.Code())
+	assert.Nil(t, ctx)
+	assert.Nil(t, cancel)
+}
+
+func TestWithDeadlineBudgetPassthrough(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true).SetTimeout(0)
+
+	parentCtx := context.Background()
+
+	ctx, cancel, commonErr := configs.withDeadlineBudget(parentCtx, testServer, testMethod)
+	require.Nil(t, commonErr)
+
+	defer cancel()
+
+	assert.Equal(t, parentCtx, ctx)
+
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestUnaryClientDeadlineInterceptor(t *testing.T) {
+	interceptor := UnaryClientDeadlineInterceptor()
+
+	var md metadata.MD
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+
+		return nil
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", "tenant-a")
+	require.NoError(t, interceptor(ctx, testMethod, nil, nil, nil, invoker))
+	assert.Empty(t, md.Get(DEADLINE_BUDGET_METADATA_KEY))
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	require.NoError(t, interceptor(ctx, testMethod, nil, nil, nil, invoker))
+	assert.Equal(t, []string{"tenant-a"}, md.Get("x-tenant-id"))
+
+	budgets := md.Get(DEADLINE_BUDGET_METADATA_KEY)
+	require.Len(t, budgets, 1)
+
+	budget, err := strconv.ParseInt(budgets[0], 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, 1000, budget, 50)
+}
+
+func TestStreamClientDeadlineInterceptor(t *testing.T) {
+	interceptor := StreamClientDeadlineInterceptor()
+
+	var md metadata.MD
+
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ = metadata.FromOutgoingContext(ctx)
+
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := interceptor(ctx, &grpc.StreamDesc{}, nil, testMethod, streamer)
+	require.NoError(t, err)
+
+	budgets := md.Get(DEADLINE_BUDGET_METADATA_KEY)
+	require.Len(t, budgets, 1)
+
+	budget, err := strconv.ParseInt(budgets[0], 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, 1000, budget, 50)
+}
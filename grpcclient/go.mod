@@ -1,35 +1,47 @@
 module github.com/twothicc/common-go/grpcclient
 
-go 1.18
+go 1.20
 
 require (
+	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
+	github.com/opentracing/opentracing-go v1.2.0
 	github.com/processout/grpc-go-pool v1.2.2-0.20200228131710-c0fcf3af0014
+	github.com/prometheus/client_golang v1.13.0
+	github.com/stretchr/testify v1.8.0
 	github.com/twothicc/common-go/commonerror v0.0.0-20220815084053-2bc49f4b1954
+	github.com/twothicc/common-go/logger v0.0.0-20220813064243-41abd81a2a39
+	github.com/uber/jaeger-client-go v2.30.0+incompatible
+	go.uber.org/zap v1.21.0
 	google.golang.org/grpc v1.48.0
+	google.golang.org/protobuf v1.28.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
-	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0 // indirect
 	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0 // indirect
 	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
-	github.com/opentracing/opentracing-go v1.2.0 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/prometheus/client_golang v1.13.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/prometheus/client_model v0.2.0 // indirect
 	github.com/prometheus/common v0.37.0 // indirect
 	github.com/prometheus/procfs v0.8.0 // indirect
-	github.com/twothicc/common-go/logger v0.0.0-20220813064243-41abd81a2a39 // indirect
-	github.com/uber/jaeger-client-go v2.30.0+incompatible // indirect
+	github.com/stretchr/objx v0.4.0 // indirect
+	github.com/twothicc/common-go/errortype v0.0.0-00010101000000-000000000000 // indirect
 	github.com/uber/jaeger-lib v2.4.1+incompatible // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
-	go.uber.org/zap v1.21.0 // indirect
 	golang.org/x/net v0.0.0-20220225172249-27dd8689420f // indirect
 	golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
 	golang.org/x/text v0.3.7 // indirect
 	google.golang.org/genproto v0.0.0-20200825200019-8632dd797987 // indirect
-	google.golang.org/protobuf v1.28.1 // indirect
+)
+
+replace (
+	github.com/twothicc/common-go/commonerror => ../commonerror
+	github.com/twothicc/common-go/errortype => ../errortype
+	github.com/twothicc/common-go/logger => ../logger
 )
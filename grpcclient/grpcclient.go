@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/twothicc/common-go/commonerror"
 	"github.com/twothicc/common-go/grpcclient/pool"
@@ -23,6 +24,7 @@ type Client struct {
 	Pools        *pool.PoolSelector
 	configs      *clientConfigs
 	tracerCloser io.Closer
+	latencies    *latencyRecorder
 }
 
 func NewClient(
@@ -31,18 +33,46 @@ func NewClient(
 ) *Client {
 	unaryClientInterceptors, streamClientInterceptors, tracerCloser := parseInterceptors(ctx, configs)
 
+	pools := pool.NewPoolSelector(
+		ctx,
+		unaryClientInterceptors,
+		streamClientInterceptors,
+		nil,
+	)
+	pools.SetDefaultConnConfigs(configs.defaultConnConfigs)
+
+	// run after setting the default connection configs, so that pool creators
+	// without connection configs of their own use them
+	for _, creatorFunc := range configs.poolCreators {
+		if err := creatorFunc(ctx, pools, false); err != nil {
+			logger.WithContext(ctx).Error("fail to create and set connection pool", zap.Error(err))
+		}
+	}
+
 	return &Client{
-		Pools: pool.NewPoolSelector(
-			ctx,
-			unaryClientInterceptors,
-			streamClientInterceptors,
-			configs.poolCreators,
-		),
+		Pools:        pools,
 		configs:      configs,
 		tracerCloser: tracerCloser,
+		latencies:    newLatencyRecorder(),
 	}
 }
 
+// Call - invokes fullMethod on server, populating resp with the result. resp must
+// be a non-nil pointer, a common error of ErrCodeInvalidArgument is returned
+// otherwise.
+//
+// server is either <domain>:<port> or the name of a logical service registered
+// with pool.ServiceCreator, in which case each attempt is balanced among the
+// endpoints of the service.
+//
+// Failed calls are retried according to the retry policy of fullMethod on server,
+// see RetryPolicy.
+//
+// Calls, including retries, are bounded by the timeout of fullMethod on server,
+// and by the ctx deadline less the configured headroom.
+//
+// Calls to idempotent methods with a hedging policy make hedged attempts, see
+// HedgingPolicy.
 func (gc *Client) Call(
 	ctx context.Context,
 	server, fullMethod string,
@@ -53,6 +83,119 @@ func (gc *Client) Call(
 		return commonerror.New(commonerror.ErrCodeServer, "grpc client not initialized")
 	}
 
+	if commonErr := validateResponse(resp); commonErr != nil {
+		return commonErr
+	}
+
+	ctx = logger.EnsureRequestID(ctx)
+
+	ctx, cancel, commonErr := gc.configs.withDeadlineBudget(ctx, server, fullMethod)
+	if commonErr != nil {
+		return commonErr
+	}
+
+	defer cancel()
+
+	policy := gc.configs.getRetryPolicy(server, fullMethod)
+	hedgingPolicy := gc.configs.getHedgingPolicy(fullMethod)
+
+	commonErr = retry(ctx, policy, server, fullMethod, func(attemptCtx context.Context) commonerror.ICommonError {
+		if policy.PerAttemptTimeout > 0 {
+			var cancel context.CancelFunc
+
+			attemptCtx, cancel = context.WithTimeout(attemptCtx, policy.PerAttemptTimeout)
+			defer cancel()
+		}
+
+		if hedgingPolicy != nil {
+			return gc.hedge(attemptCtx, hedgingPolicy, server, fullMethod, req, resp)
+		}
+
+		return gc.call(attemptCtx, server, fullMethod, req, resp)
+	})
+	if commonErr != nil {
+		return commonErr
+	}
+
+	return nil
+}
+
+// call - invokes fullMethod on server once, failing fast if the circuit breaker
+// of server is open.
+//
+// If server is the name of a logical service, an endpoint of the service is
+// picked and called instead, see pool.PoolSelector.Pick.
+func (gc *Client) call(
+	ctx context.Context,
+	server, fullMethod string,
+	req interface{},
+	resp interface{},
+) commonerror.ICommonError {
+	address, report, commonErr := gc.allow(server)
+	if commonErr != nil {
+		return commonErr
+	}
+
+	commonErr = gc.invoke(ctx, address, fullMethod, req, resp)
+	report(commonErr)
+
+	return commonErr
+}
+
+// allow - picks the address to call for server, other than excluded addresses if
+// possible, and checks its circuit breaker.
+//
+// report must be called with the result of the call.
+func (gc *Client) allow(server string, excluded ...string) (
+	address string,
+	report func(err error),
+	commonErr commonerror.ICommonError,
+) {
+	address, reportEndpoint, err := gc.Pools.Pick(server, excluded...)
+	if err != nil {
+		return "", nil, commonerror.Convert(err)
+	}
+
+	reportBreaker, err := gc.Pools.Breaker(address).Allow()
+	if err != nil {
+		commonErr = commonerror.Convert(err)
+		reportEndpoint(commonErr)
+
+		return "", nil, commonErr
+	}
+
+	return address, func(err error) {
+		reportBreaker(err)
+		reportEndpoint(err)
+	}, nil
+}
+
+// invoke - invokes fullMethod on server with a connection from its pool.
+func (gc *Client) invoke(
+	ctx context.Context,
+	server, fullMethod string,
+	req interface{},
+	resp interface{},
+) commonerror.ICommonError {
+	conn, commonErr := gc.getConn(ctx, server)
+	if commonErr != nil {
+		return commonErr
+	}
+
+	defer returnOrCloseConnection(ctx, server, conn)
+
+	if err := conn.Invoke(ctx, fullMethod, req, resp); err != nil {
+		return commonerror.Convert(err)
+	}
+
+	return nil
+}
+
+// getConn - gets a connection from the pool of server, creating the pool if it
+// does not exist.
+//
+// The connection must be returned with returnOrCloseConnection.
+func (gc *Client) getConn(ctx context.Context, server string) (*grpc_pool.ClientConn, commonerror.ICommonError) {
 	conn, err := gc.Pools.Get(ctx, server, true)
 	if err != nil {
 		logger.WithContext(ctx).Debug("fail to get connection pool", zap.String("server", server))
@@ -64,17 +207,10 @@ func (gc *Client) Call(
 			code = commonerror.ErrCodeTimeout
 		}
 
-		return commonerror.New(int32(code), msg)
-	}
-
-	defer returnOrCloseConnection(ctx, server, conn)
-
-	err = conn.Invoke(ctx, fullMethod, req, resp)
-	if err != nil {
-		return commonerror.Convert(err)
+		return nil, commonerror.New(int32(code), msg)
 	}
 
-	return nil
+	return conn, nil
 }
 
 // Close - closes client
@@ -93,12 +229,33 @@ func (gc *Client) Close(ctx context.Context) {
 	}
 }
 
+// sleepContext - waits for duration, returning early with ctx's error if ctx is done first.
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // returnOrCloseConnection - returns connection obj to pool, or close underlying connection if pool is full
+// or closed, e.g. after the endpoint of a service is removed
 func returnOrCloseConnection(ctx context.Context, server string, conn *grpc_pool.ClientConn) {
 	if err := conn.Close(); err != nil {
 		if errors.Is(err, grpc_pool.ErrFullPool) {
 			logger.WithContext(ctx).Debug("pool capacity reached, closing connection", zap.String("server", server))
 			conn.ClientConn.Close()
+		} else if errors.Is(err, grpc_pool.ErrClosed) {
+			logger.WithContext(ctx).Debug("pool closed, closing connection", zap.String("server", server))
+			conn.ClientConn.Close()
 		} else {
 			logger.WithContext(ctx).Error("Fail to return connection to connection pool",
 				zap.String("server", server),
@@ -129,11 +286,16 @@ func parseInterceptors(
 	unaryClientInterceptors = []grpc.UnaryClientInterceptor{
 		grpc_opentracing.UnaryClientInterceptor(),
 		grpc_zap.UnaryClientInterceptor(logger.WithContext(ctx)),
+		UnaryClientMetadataInterceptor(configs.forwardedMDKeys),
+		UnaryClientDeadlineInterceptor(),
 	}
 
 	streamClientInterceptors = []grpc.StreamClientInterceptor{
+		streamClientRetryInterceptor(configs.getRetryPolicy),
 		grpc_opentracing.StreamClientInterceptor(),
 		grpc_zap.StreamClientInterceptor(logger.WithContext(ctx)),
+		StreamClientMetadataInterceptor(configs.forwardedMDKeys),
+		StreamClientDeadlineInterceptor(),
 	}
 
 	return unaryClientInterceptors, streamClientInterceptors, tracerCloser
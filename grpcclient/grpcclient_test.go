@@ -0,0 +1,208 @@
+package grpcclient
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+	"github.com/twothicc/common-go/grpcclient/pool"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	testService       = "echo"
+	echoMethod        = "/test.Echo/Echo"
+	listMethod        = "/test.Echo/List"
+	countMethod       = "/test.Echo/Count"
+	chatMethod        = "/test.Echo/Chat"
+	notFoundValue     = "notfound"
+	failValue         = "fail"
+	testListResponses = 3
+)
+
+// echoService - handler type of the test service.
+type echoService interface{}
+
+// startEchoServer - starts a server of the test service, responding to unary
+// calls after delay, returning its address.
+//
+// Echo responds with "hi <value>", List streams testListResponses copies of the
+// request, Count responds with the number of requests streamed and Chat echoes
+// every request. Requests of notFoundValue fail with ErrCodeNotFound, and List
+// fails with ErrCodeUnavailable after its first response for failValue.
+func startEchoServer(t *testing.T, delay time.Duration) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Echo",
+		HandlerType: (*echoService)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "Echo",
+				Handler: func(
+					srv interface{},
+					ctx context.Context,
+					dec func(interface{}) error,
+					_ grpc.UnaryServerInterceptor,
+				) (interface{}, error) {
+					req := &wrapperspb.StringValue{}
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+
+					select {
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					case <-time.After(delay):
+					}
+
+					if req.GetValue() == notFoundValue {
+						return nil, commonerror.New(commonerror.ErrCodeNotFound, "not found").GRPCStatus().Err()
+					}
+
+					return wrapperspb.String("hi " + req.GetValue()), nil
+				},
+			},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "List",
+				ServerStreams: true,
+				Handler: func(srv interface{}, stream grpc.ServerStream) error {
+					req := &wrapperspb.StringValue{}
+					if err := stream.RecvMsg(req); err != nil {
+						return err
+					}
+
+					for i := 0; i < testListResponses; i++ {
+						if i > 0 && req.GetValue() == failValue {
+							return commonerror.New(commonerror.ErrCodeUnavailable, "unavailable").GRPCStatus().Err()
+						}
+
+						if err := stream.SendMsg(req); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+			},
+			{
+				StreamName:    "Count",
+				ClientStreams: true,
+				Handler: func(srv interface{}, stream grpc.ServerStream) error {
+					count := int32(0)
+
+					for {
+						err := stream.RecvMsg(&wrapperspb.StringValue{})
+						if err == io.EOF {
+							return stream.SendMsg(wrapperspb.Int32(count))
+						}
+
+						if err != nil {
+							return err
+						}
+
+						count++
+					}
+				},
+			},
+			{
+				StreamName:    "Chat",
+				ServerStreams: true,
+				ClientStreams: true,
+				Handler: func(srv interface{}, stream grpc.ServerStream) error {
+					for {
+						req := &wrapperspb.StringValue{}
+
+						err := stream.RecvMsg(req)
+						if err == io.EOF {
+							return nil
+						}
+
+						if err != nil {
+							return err
+						}
+
+						if err := stream.SendMsg(req); err != nil {
+							return err
+						}
+					}
+				},
+			},
+		},
+	}, nil)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+// newTestClient - creates a client of testService served by endpoints, with a
+// connection pool of a single connection per endpoint.
+func newTestClient(t *testing.T, configs *clientConfigs, endpoints ...string) *Client {
+	t.Helper()
+
+	connConfigs := pool.GetConnConfigs(
+		pool.DEFAULT_IDLE_TIMEOUT,
+		pool.DEFAULT_CREATE_TIMEOUT,
+		pool.DEFAULT_MAX_LIFE_DURATION,
+		0, 1,
+		false,
+	)
+
+	configs.poolCreators = append(configs.poolCreators, pool.ServiceCreator(
+		pool.GetServiceConfigs(testService, pool.RoundRobin, connConfigs, endpoints...),
+		nil,
+		nil,
+	))
+
+	client := NewClient(context.Background(), configs)
+	t.Cleanup(func() {
+		client.Close(context.Background())
+	})
+
+	return client
+}
+
+func TestNewClientDefaultConnConfigs(t *testing.T) {
+	client := NewClient(context.Background(), GetDefaultClientConfigs("test", true))
+	defer client.Close(context.Background())
+
+	assert.NotNil(t, client.Pools.Breaker(testServer))
+
+	client = NewClient(context.Background(), GetDefaultClientConfigs("test", true).SetBreakerConfigs(nil))
+	defer client.Close(context.Background())
+
+	assert.Nil(t, client.Pools.Breaker(testServer))
+	assert.Equal(t, pool.BreakerClosed, client.Pools.Breaker(testServer).State())
+}
+
+func TestNewClientServiceDefaultConnConfigs(t *testing.T) {
+	address := startEchoServer(t, 0)
+
+	configs := GetDefaultClientConfigs("test", true, pool.ServiceCreator(
+		pool.GetServiceConfigs(testService, pool.RoundRobin, nil, address),
+		nil,
+		nil,
+	)).SetBreakerConfigs(nil)
+
+	client := NewClient(context.Background(), configs)
+	defer client.Close(context.Background())
+
+	assert.Nil(t, client.Pools.Breaker(address))
+}
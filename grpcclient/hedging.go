@@ -0,0 +1,214 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/twothicc/common-go/commonerror"
+	"github.com/twothicc/common-go/logger"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// HedgingPolicy - configures hedged attempts of calls to an idempotent method.
+//
+// If no attempt has succeeded after the hedging delay, another attempt is made
+// on a different connection, or a different endpoint of a logical service, up to
+// MaxAttempts attempts. The first successful response is taken and the other
+// attempts are cancelled. If an attempt fails while no other attempt is in
+// flight, its error is returned, to be retried according to the retry policy.
+//
+// The hedging delay is the DelayPercentile latency of the latest successful
+// attempts of the method, or Delay until enough latencies are recorded.
+type HedgingPolicy struct {
+	Delay           time.Duration
+	DelayPercentile float64 // within (0, 1], e.g. 0.95, 0 to always wait Delay
+	MaxAttempts     int     // including the first attempt
+}
+
+// GetDefaultHedgingPolicy - returns a policy making a second attempt after the
+// 95th percentile latency.
+func GetDefaultHedgingPolicy() *HedgingPolicy {
+	return &HedgingPolicy{
+		Delay:           DEFAULT_HEDGING_DELAY,
+		DelayPercentile: DEFAULT_HEDGING_DELAY_PERCENTILE,
+		MaxAttempts:     DEFAULT_HEDGING_MAX_ATTEMPTS,
+	}
+}
+
+var (
+	hedgedCallsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_hedged_calls_total",
+		Help: "Total number of calls on which hedged attempts were made.",
+	}, []string{"grpc_service", "grpc_method"})
+	hedgedAttemptsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_hedged_attempts_total",
+		Help: "Total number of hedged attempts made, excluding the first attempt of calls.",
+	}, []string{"grpc_service", "grpc_method"})
+	hedgeWinsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_hedge_wins_total",
+		Help: "Total number of calls whose response was taken from a hedged attempt.",
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// hedgeResult - result of an attempt of a hedged call.
+type hedgeResult struct {
+	resp      interface{}
+	commonErr commonerror.ICommonError
+	hedge     int
+}
+
+// hedge - invokes fullMethod on server with hedged attempts according to policy,
+// populating resp with the first successful response.
+//
+// The index of the attempt, 0 for the first attempt, is tagged on its client span,
+// and the number of hedged attempts on the span of ctx, if any.
+func (gc *Client) hedge(
+	ctx context.Context,
+	policy *HedgingPolicy,
+	server, fullMethod string,
+	req interface{},
+	resp interface{},
+) commonerror.ICommonError {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, policy.MaxAttempts)
+	addresses := make([]string, 0, policy.MaxAttempts)
+
+	attempt := func(hedge int) {
+		address, report, commonErr := gc.allow(server, addresses...)
+		if commonErr != nil {
+			results <- hedgeResult{commonErr: commonErr, hedge: hedge}
+
+			return
+		}
+
+		addresses = append(addresses, address)
+		attemptCtx := withClientSpanTags(ctx, opentracing.Tags{HEDGE_TAG: hedge})
+
+		go func() {
+			attemptResp := reflect.New(reflect.TypeOf(resp).Elem()).Interface()
+			start := time.Now()
+
+			commonErr := gc.invoke(attemptCtx, address, fullMethod, req, attemptResp)
+			report(commonErr)
+
+			if commonErr == nil {
+				gc.latencies.record(fullMethod, time.Since(start))
+			}
+
+			results <- hedgeResult{resp: attemptResp, commonErr: commonErr, hedge: hedge}
+		}()
+	}
+
+	delay := policy.Delay
+	if policy.DelayPercentile > 0 {
+		if latency, ok := gc.latencies.percentile(fullMethod, policy.DelayPercentile); ok {
+			delay = latency
+		}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	service, method := splitFullMethod(fullMethod)
+	attempts, inFlight := 1, 1
+
+	defer func() {
+		if span := opentracing.SpanFromContext(ctx); span != nil && attempts > 1 {
+			span.SetTag(HEDGED_ATTEMPTS_TAG, attempts-1)
+		}
+	}()
+
+	attempt(0)
+
+	for {
+		select {
+		case <-timer.C:
+			if attempts >= policy.MaxAttempts {
+				continue
+			}
+
+			if attempts == 1 {
+				hedgedCallsCounter.WithLabelValues(service, method).Inc()
+			}
+
+			hedgedAttemptsCounter.WithLabelValues(service, method).Inc()
+
+			attempt(attempts)
+			attempts++
+			inFlight++
+
+			timer.Reset(delay)
+		case result := <-results:
+			inFlight--
+
+			if result.commonErr == nil {
+				setResponse(resp, result.resp)
+
+				if result.hedge > 0 {
+					hedgeWinsCounter.WithLabelValues(service, method).Inc()
+				}
+
+				if attempts > 1 {
+					logger.WithContext(ctx).Debug("hedged call succeeded",
+						zap.String("server", server),
+						zap.String("method", fullMethod),
+						zap.Int("attempts", attempts),
+						zap.Int("hedge", result.hedge),
+					)
+				}
+
+				return nil
+			}
+
+			if inFlight == 0 {
+				return result.commonErr
+			}
+		}
+	}
+}
+
+// validateResponse - checks that resp is a non-nil pointer, which hedged attempts
+// allocate responses of the pointed type of.
+func validateResponse(resp interface{}) commonerror.ICommonError {
+	if resp == nil || reflect.TypeOf(resp).Kind() != reflect.Pointer || reflect.ValueOf(resp).IsNil() {
+		return commonerror.New(
+			commonerror.ErrCodeInvalidArgument,
+			fmt.Sprintf("resp must be a non-nil pointer, resp = %T", resp),
+		)
+	}
+
+	return nil
+}
+
+// setResponse - sets resp to src, a response of the same type.
+//
+// Protobuf messages are merged into a reset resp, other responses are copied.
+func setResponse(resp, src interface{}) {
+	msg, ok := resp.(proto.Message)
+	srcMsg, srcOk := src.(proto.Message)
+
+	if ok && srcOk {
+		proto.Reset(msg)
+		proto.Merge(msg, srcMsg)
+
+		return
+	}
+
+	reflect.ValueOf(resp).Elem().Set(reflect.ValueOf(src).Elem())
+}
+
+// splitFullMethod - splits /<service>/<method> into its service and method.
+func splitFullMethod(fullMethod string) (service, method string) {
+	service, method, _ = strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+
+	return service, method
+}
@@ -0,0 +1,257 @@
+package grpcclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// hedgeCounts - values of the hedging counters of echoMethod.
+type hedgeCounts struct {
+	calls    float64
+	attempts float64
+	wins     float64
+}
+
+// getHedgeCounts - returns the current values of the hedging counters of echoMethod.
+func getHedgeCounts() hedgeCounts {
+	service, method := splitFullMethod(echoMethod)
+
+	return hedgeCounts{
+		calls:    testutil.ToFloat64(hedgedCallsCounter.WithLabelValues(service, method)),
+		attempts: testutil.ToFloat64(hedgedAttemptsCounter.WithLabelValues(service, method)),
+		wins:     testutil.ToFloat64(hedgeWinsCounter.WithLabelValues(service, method)),
+	}
+}
+
+// assertHedgeCounts - asserts the increase of the hedging counters of echoMethod
+// since before.
+func assertHedgeCounts(t *testing.T, before hedgeCounts, calls, attempts, wins float64) {
+	t.Helper()
+
+	after := getHedgeCounts()
+	assert.Equal(t, calls, after.calls-before.calls, "hedged calls")
+	assert.Equal(t, attempts, after.attempts-before.attempts, "hedged attempts")
+	assert.Equal(t, wins, after.wins-before.wins, "hedge wins")
+}
+
+// assertAttemptsEnded - asserts that the attempts to all endpoints of testService
+// end soon, e.g. once cancelled, rather than after the delay of the server.
+func assertAttemptsEnded(t *testing.T, client *Client) {
+	t.Helper()
+
+	assert.Eventually(t, func() bool {
+		for _, endpoint := range client.Pools.ServiceEndpoints(testService) {
+			if endpoint.Outstanding() != 0 {
+				return false
+			}
+		}
+
+		return true
+	}, 500*time.Millisecond, 10*time.Millisecond)
+}
+
+// testHedgingPolicy - returns a policy hedging after delay, up to maxAttempts.
+func testHedgingPolicy(delay time.Duration, maxAttempts int) *HedgingPolicy {
+	return &HedgingPolicy{
+		Delay:       delay,
+		MaxAttempts: maxAttempts,
+	}
+}
+
+func TestHedgeFirstSuccessWins(t *testing.T) {
+	slowAddress, fastAddress := startEchoServer(t, time.Second), startEchoServer(t, 0)
+
+	configs := GetDefaultClientConfigs("test", true).SetHedgingPolicy(echoMethod, testHedgingPolicy(20*time.Millisecond, 2))
+	client := newTestClient(t, configs, slowAddress, fastAddress)
+
+	before := getHedgeCounts()
+	start := time.Now()
+
+	resp := &wrapperspb.StringValue{}
+	require.NoError(t, client.Call(context.Background(), testService, echoMethod, wrapperspb.String("alice"), resp))
+
+	assert.Equal(t, "hi alice", resp.GetValue())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assertHedgeCounts(t, before, 1, 1, 1)
+
+	// the slow attempt is cancelled rather than waited for
+	require.Equal(t, slowAddress, client.Pools.ServiceEndpoints(testService)[0].Address)
+	assertAttemptsEnded(t, client)
+}
+
+func TestHedgeNotNeeded(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true).SetHedgingPolicy(echoMethod, testHedgingPolicy(time.Second, 2))
+	client := newTestClient(t, configs, startEchoServer(t, 0), startEchoServer(t, 0))
+
+	before := getHedgeCounts()
+
+	resp := &wrapperspb.StringValue{}
+	require.NoError(t, client.Call(context.Background(), testService, echoMethod, wrapperspb.String("alice"), resp))
+
+	assert.Equal(t, "hi alice", resp.GetValue())
+	assertHedgeCounts(t, before, 0, 0, 0)
+}
+
+func TestHedgeMaxAttempts(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true).SetHedgingPolicy(echoMethod, testHedgingPolicy(10*time.Millisecond, 2))
+	client := newTestClient(t, configs,
+		startEchoServer(t, 100*time.Millisecond),
+		startEchoServer(t, 100*time.Millisecond),
+		startEchoServer(t, 100*time.Millisecond),
+	)
+
+	before := getHedgeCounts()
+
+	resp := &wrapperspb.StringValue{}
+	require.NoError(t, client.Call(context.Background(), testService, echoMethod, wrapperspb.String("alice"), resp))
+
+	assert.Equal(t, "hi alice", resp.GetValue())
+
+	// either attempt may win, but only 1 hedged attempt is made
+	after := getHedgeCounts()
+	assert.Equal(t, 1.0, after.calls-before.calls)
+	assert.Equal(t, 1.0, after.attempts-before.attempts)
+	assertAttemptsEnded(t, client)
+}
+
+func TestHedgeAllFailed(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true).SetHedgingPolicy(echoMethod, testHedgingPolicy(10*time.Millisecond, 2))
+	client := newTestClient(t, configs, startEchoServer(t, 50*time.Millisecond), startEchoServer(t, 50*time.Millisecond))
+
+	before := getHedgeCounts()
+
+	err := client.Call(context.Background(), testService, echoMethod, wrapperspb.String(notFoundValue), &wrapperspb.StringValue{})
+	require.Error(t, err)
+	assert.Equal(t, int32(commonerror.ErrCodeNotFound), commonerror.Convert(err).Code())
+	assertHedgeCounts(t, before, 1, 1, 0)
+	assertAttemptsEnded(t, client)
+}
+
+func TestHedgePercentileDelay(t *testing.T) {
+	slowAddress, fastAddress := startEchoServer(t, time.Second), startEchoServer(t, 0)
+
+	policy := &HedgingPolicy{
+		Delay:           5 * time.Second,
+		DelayPercentile: 0.95,
+		MaxAttempts:     2,
+	}
+	client := newTestClient(t, GetDefaultClientConfigs("test", true).SetHedgingPolicy(echoMethod, policy), slowAddress, fastAddress)
+
+	for i := 0; i < HEDGING_MIN_LATENCY_SAMPLES; i++ {
+		client.latencies.record(echoMethod, 10*time.Millisecond)
+	}
+
+	start := time.Now()
+
+	resp := &wrapperspb.StringValue{}
+	require.NoError(t, client.Call(context.Background(), testService, echoMethod, wrapperspb.String("alice"), resp))
+
+	assert.Equal(t, "hi alice", resp.GetValue())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assertAttemptsEnded(t, client)
+}
+
+func TestGetHedgingPolicy(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true)
+	assert.Nil(t, configs.getHedgingPolicy(echoMethod))
+
+	configs.SetHedgingPolicy(echoMethod, testHedgingPolicy(time.Millisecond, 1))
+	assert.Nil(t, configs.getHedgingPolicy(echoMethod))
+
+	policy := GetDefaultHedgingPolicy()
+	configs.SetHedgingPolicy(echoMethod, policy)
+	assert.Same(t, policy, configs.getHedgingPolicy(echoMethod))
+
+	configs.SetHedgingPolicy(echoMethod, nil)
+	assert.Nil(t, configs.getHedgingPolicy(echoMethod))
+}
+
+func TestLatencyRecorderPercentile(t *testing.T) {
+	recorder := newLatencyRecorder()
+
+	_, ok := recorder.percentile(echoMethod, 0.95)
+	assert.False(t, ok)
+
+	for i := 1; i < HEDGING_MIN_LATENCY_SAMPLES; i++ {
+		recorder.record(echoMethod, time.Duration(i)*time.Millisecond)
+	}
+
+	_, ok = recorder.percentile(echoMethod, 0.95)
+	assert.False(t, ok)
+
+	for i := HEDGING_MIN_LATENCY_SAMPLES; i <= HEDGING_LATENCY_SAMPLES; i++ {
+		recorder.record(echoMethod, time.Duration(i)*time.Millisecond)
+	}
+
+	latency, ok := recorder.percentile(echoMethod, 0.95)
+	require.True(t, ok)
+	assert.Equal(t, 95*time.Millisecond, latency)
+
+	latency, _ = recorder.percentile(echoMethod, 1)
+	assert.Equal(t, 100*time.Millisecond, latency)
+
+	latency, _ = recorder.percentile(echoMethod, 0.001)
+	assert.Equal(t, time.Millisecond, latency)
+
+	_, ok = recorder.percentile(listMethod, 0.95)
+	assert.False(t, ok)
+
+	// the oldest latencies are replaced once the window is full
+	for i := 0; i < HEDGING_LATENCY_SAMPLES/2; i++ {
+		recorder.record(echoMethod, time.Second)
+	}
+
+	latency, _ = recorder.percentile(echoMethod, 0.5)
+	assert.Equal(t, 100*time.Millisecond, latency)
+
+	latency, _ = recorder.percentile(echoMethod, 0.51)
+	assert.Equal(t, time.Second, latency)
+}
+
+func TestSetResponse(t *testing.T) {
+	resp := wrapperspb.String("stale")
+	setResponse(resp, wrapperspb.String("fresh"))
+	assert.Equal(t, "fresh", resp.GetValue())
+
+	setResponse(resp, wrapperspb.String(""))
+	assert.Equal(t, "", resp.GetValue())
+
+	type response struct {
+		Values []string
+		Count  int
+	}
+
+	plainResp := &response{Values: []string{"stale"}, Count: 1}
+	setResponse(plainResp, &response{Values: []string{"fresh"}})
+	assert.Equal(t, &response{Values: []string{"fresh"}}, plainResp)
+}
+
+func TestCallInvalidResponse(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true).SetHedgingPolicy(echoMethod, testHedgingPolicy(time.Second, 2))
+	client := newTestClient(t, configs, startEchoServer(t, 0))
+
+	var nilResp *wrapperspb.StringValue
+
+	for _, resp := range []interface{}{nil, nilResp, wrapperspb.StringValue{}} {
+		err := client.Call(context.Background(), testService, echoMethod, wrapperspb.String("alice"), resp)
+		require.Error(t, err)
+		assert.Equal(t, int32(commonerror.ErrCodeInvalidArgument), commonerror.Convert(err).Code())
+	}
+}
+
+func TestSplitFullMethod(t *testing.T) {
+	service, method := splitFullMethod(echoMethod)
+	assert.Equal(t, "test.Echo", service)
+	assert.Equal(t, "Echo", method)
+
+	service, method = splitFullMethod("invalid")
+	assert.Equal(t, "invalid", service)
+	assert.Equal(t, "", method)
+}
@@ -0,0 +1,33 @@
+// Package testlogger - initializes the logger for the tests of grpcclient
+// packages.
+package testlogger
+
+import (
+	"os"
+	"testing"
+
+	"github.com/twothicc/common-go/logger"
+	"go.uber.org/zap/zapcore"
+)
+
+// Main - runs the tests of m with the logger initialized at debug level, writing
+// its log file to a temporary directory, then exits with their exit code.
+//
+// Called by the TestMain of each package logging through the logger.
+func Main(m *testing.M) {
+	dir, err := os.MkdirTemp("", "grpcclient")
+	if err != nil {
+		panic(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+
+	logger.InitLogger(zapcore.DebugLevel)
+
+	code := m.Run()
+
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
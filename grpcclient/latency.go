@@ -0,0 +1,76 @@
+package grpcclient
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencyRecorder - records the latencies of the latest successful attempts of
+// each method.
+type latencyRecorder struct {
+	windows map[string]*latencyWindow
+	mu      sync.Mutex
+}
+
+// latencyWindow - ring of the latest HEDGING_LATENCY_SAMPLES latencies.
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+}
+
+func newLatencyRecorder() *latencyRecorder {
+	return &latencyRecorder{
+		windows: make(map[string]*latencyWindow),
+	}
+}
+
+// record - records latency of an attempt of fullMethod.
+func (r *latencyRecorder) record(fullMethod string, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	window, ok := r.windows[fullMethod]
+	if !ok {
+		window = &latencyWindow{
+			samples: make([]time.Duration, 0, HEDGING_LATENCY_SAMPLES),
+		}
+		r.windows[fullMethod] = window
+	}
+
+	if len(window.samples) < HEDGING_LATENCY_SAMPLES {
+		window.samples = append(window.samples, latency)
+	} else {
+		window.samples[window.next] = latency
+	}
+
+	window.next = (window.next + 1) % HEDGING_LATENCY_SAMPLES
+}
+
+// percentile - returns the latency of fullMethod at percentile, within (0, 1],
+// false if fewer than HEDGING_MIN_LATENCY_SAMPLES latencies are recorded.
+func (r *latencyRecorder) percentile(fullMethod string, percentile float64) (time.Duration, bool) {
+	r.mu.Lock()
+
+	window, ok := r.windows[fullMethod]
+	if !ok || len(window.samples) < HEDGING_MIN_LATENCY_SAMPLES {
+		r.mu.Unlock()
+
+		return 0, false
+	}
+
+	samples := append([]time.Duration(nil), window.samples...)
+	r.mu.Unlock()
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i] < samples[j]
+	})
+
+	index := int(math.Ceil(math.Min(percentile, 1)*float64(len(samples)))) - 1
+	if index < 0 {
+		index = 0
+	}
+
+	return samples[index], true
+}
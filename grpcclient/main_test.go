@@ -0,0 +1,11 @@
+package grpcclient
+
+import (
+	"testing"
+
+	"github.com/twothicc/common-go/grpcclient/internal/testlogger"
+)
+
+func TestMain(m *testing.M) {
+	testlogger.Main(m)
+}
@@ -0,0 +1,74 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/twothicc/common-go/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryClientMetadataInterceptor - forwards the incoming metadata of keys from
+// the server-side ctx to outgoing metadata of calls, along with the request id.
+//
+// Outgoing metadata already set for a key is kept. The request id carried by ctx
+// is used, or else one is generated.
+func UnaryClientMetadataInterceptor(keys []string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(withForwardedMetadata(ctx, keys), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientMetadataInterceptor - forwards the incoming metadata of keys from
+// the server-side ctx to outgoing metadata of streams, along with the request id.
+//
+// Outgoing metadata already set for a key is kept. The request id carried by ctx
+// is used, or else one is generated.
+func StreamClientMetadataInterceptor(keys []string) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(withForwardedMetadata(ctx, keys), desc, cc, method, opts...)
+	}
+}
+
+// withForwardedMetadata - copies the incoming metadata of keys and the request id
+// of ctx into outgoing metadata.
+func withForwardedMetadata(ctx context.Context, keys []string) context.Context {
+	incomingMD, _ := metadata.FromIncomingContext(ctx)
+	outgoingMD, _ := metadata.FromOutgoingContext(ctx)
+	outgoingMD = outgoingMD.Copy()
+
+	for _, key := range keys {
+		if len(outgoingMD.Get(key)) > 0 {
+			continue
+		}
+
+		if values := incomingMD.Get(key); len(values) > 0 {
+			outgoingMD.Set(key, values...)
+		}
+	}
+
+	if len(outgoingMD.Get(logger.REQUEST_ID_METADATA_KEY)) == 0 {
+		requestID := logger.RequestID(ctx)
+		if requestID == "" {
+			requestID = logger.NewRequestID()
+		}
+
+		outgoingMD.Set(logger.REQUEST_ID_METADATA_KEY, requestID)
+	}
+
+	return metadata.NewOutgoingContext(ctx, outgoingMD)
+}
@@ -0,0 +1,60 @@
+package grpcclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twothicc/common-go/logger"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWithForwardedMetadata(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-tenant", "tenant-a",
+		"x-user", "alice",
+		"authorization", "secret",
+	))
+	ctx = logger.WithRequestID(ctx, "abc")
+
+	md, _ := metadata.FromOutgoingContext(withForwardedMetadata(ctx, []string{"x-tenant", "x-user", "x-missing"}))
+
+	assert.Equal(t, metadata.Pairs(
+		"x-tenant", "tenant-a",
+		"x-user", "alice",
+		logger.REQUEST_ID_METADATA_KEY, "abc",
+	), md)
+}
+
+func TestWithForwardedMetadataKeepsOutgoing(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-tenant", "tenant-a",
+		logger.REQUEST_ID_METADATA_KEY, "incoming",
+	))
+	ctx = logger.WithRequestID(ctx, "abc")
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs(
+		"x-tenant", "tenant-b",
+		logger.REQUEST_ID_METADATA_KEY, "outgoing",
+		"x-other", "other",
+	))
+
+	forwardedCtx := withForwardedMetadata(ctx, []string{"x-tenant"})
+	md, _ := metadata.FromOutgoingContext(forwardedCtx)
+
+	assert.Equal(t, metadata.Pairs(
+		"x-tenant", "tenant-b",
+		logger.REQUEST_ID_METADATA_KEY, "outgoing",
+		"x-other", "other",
+	), md)
+
+	originalMD, _ := metadata.FromOutgoingContext(ctx)
+	assert.Len(t, originalMD, 3)
+}
+
+func TestWithForwardedMetadataGeneratesRequestID(t *testing.T) {
+	md, _ := metadata.FromOutgoingContext(withForwardedMetadata(context.Background(), nil))
+
+	requestIDs := md.Get(logger.REQUEST_ID_METADATA_KEY)
+	assert.Len(t, requestIDs, 1)
+	assert.Len(t, requestIDs[0], 2*logger.REQUEST_ID_BYTES)
+}
@@ -0,0 +1,50 @@
+package grpcclient
+
+import "context"
+
+// Method - typed handle of a unary method on a server, giving compile-time
+// request and response types to calls made through Client.Call.
+//
+// Req and Resp are the request and response message types, e.g. pb.HelloRequest
+// and pb.HelloResponse.
+type Method[Req, Resp any] struct {
+	client     *Client
+	server     string
+	fullMethod string
+}
+
+// NewMethod - creates a typed handle of fullMethod on server, e.g.
+// grpcclient.NewMethod[pb.HelloRequest, pb.HelloResponse](client, "localhost:8080", "/helloworld.Greeter/SayHello").
+//
+// Should be created once, e.g. at init time, and shared.
+func NewMethod[Req, Resp any](client *Client, server, fullMethod string) *Method[Req, Resp] {
+	return &Method[Req, Resp]{
+		client:     client,
+		server:     server,
+		fullMethod: fullMethod,
+	}
+}
+
+// Call - invokes the method with req, returning the response.
+//
+// Calls are made through Client.Call, so that they are pooled, retried and
+// their errors converted into common errors.
+func (m *Method[Req, Resp]) Call(ctx context.Context, req *Req) (*Resp, error) {
+	resp := new(Resp)
+
+	if err := m.client.Call(ctx, m.server, m.fullMethod, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Server - returns the server of the method.
+func (m *Method[Req, Resp]) Server() string {
+	return m.server
+}
+
+// FullMethod - returns the full name of the method.
+func (m *Method[Req, Resp]) FullMethod() string {
+	return m.fullMethod
+}
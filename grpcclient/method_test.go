@@ -0,0 +1,52 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMethodCall(t *testing.T) {
+	client := newTestClient(t, GetDefaultClientConfigs("test", true), startEchoServer(t, 0))
+
+	method := NewMethod[wrapperspb.StringValue, wrapperspb.StringValue](client, testService, echoMethod)
+	assert.Equal(t, testService, method.Server())
+	assert.Equal(t, echoMethod, method.FullMethod())
+
+	resp, err := method.Call(context.Background(), wrapperspb.String("alice"))
+	require.NoError(t, err)
+	assert.Equal(t, "hi alice", resp.GetValue())
+}
+
+func TestMethodCallError(t *testing.T) {
+	client := newTestClient(t, GetDefaultClientConfigs("test", true), startEchoServer(t, 0))
+
+	method := NewMethod[wrapperspb.StringValue, wrapperspb.StringValue](client, testService, echoMethod)
+
+	resp, err := method.Call(context.Background(), wrapperspb.String(notFoundValue))
+	assert.Nil(t, resp)
+
+	var commonErr commonerror.ICommonError
+	require.True(t, errors.As(err, &commonErr))
+	assert.Equal(t, int32(commonerror.ErrCodeNotFound), commonErr.Code())
+	assert.Equal(t, "not found", commonErr.Msg())
+}
+
+func TestMethodCallConversionError(t *testing.T) {
+	client := newTestClient(t, GetDefaultClientConfigs("test", true), startEchoServer(t, 0))
+
+	method := NewMethod[wrapperspb.StringValue, struct{ Value string }](client, testService, echoMethod)
+
+	resp, err := method.Call(context.Background(), wrapperspb.String("alice"))
+	assert.Nil(t, resp)
+
+	var commonErr commonerror.ICommonError
+	require.True(t, errors.As(err, &commonErr))
+	assert.Equal(t, codes.Internal, commonErr.GRPCCode())
+}
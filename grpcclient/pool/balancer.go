@@ -0,0 +1,97 @@
+package pool
+
+import (
+	"math/rand"
+	"sync/atomic"
+)
+
+// BalancerPolicy - policy of picking an endpoint of a service for each call.
+type BalancerPolicy int32
+
+const (
+	RoundRobin        BalancerPolicy = iota // endpoints in turn
+	LeastOutstanding                        // endpoint with the fewest outstanding calls
+	PowerOfTwoChoices                       // endpoint with fewer outstanding calls of 2 random endpoints
+)
+
+var balancerPolicyNames = map[BalancerPolicy]string{
+	RoundRobin:        "round_robin",
+	LeastOutstanding:  "least_outstanding",
+	PowerOfTwoChoices: "power_of_two_choices",
+}
+
+// String - returns the name of BalancerPolicy.
+func (p BalancerPolicy) String() string {
+	return balancerPolicyNames[p]
+}
+
+// balancer - picks an endpoint out of non-empty endpoints.
+type balancer interface {
+	pick(endpoints []*Endpoint) *Endpoint
+}
+
+// newBalancer - creates a balancer of policy, round robin if policy is unknown.
+func newBalancer(policy BalancerPolicy) balancer {
+	switch policy {
+	case LeastOutstanding:
+		return &leastOutstandingBalancer{}
+	case PowerOfTwoChoices:
+		return &powerOfTwoChoicesBalancer{}
+	case RoundRobin:
+	}
+
+	return &roundRobinBalancer{}
+}
+
+// roundRobinBalancer - picks endpoints in turn.
+type roundRobinBalancer struct {
+	next uint64
+}
+
+func (b *roundRobinBalancer) pick(endpoints []*Endpoint) *Endpoint {
+	next := atomic.AddUint64(&b.next, 1) - 1
+
+	return endpoints[next%uint64(len(endpoints))]
+}
+
+// leastOutstandingBalancer - picks the endpoint with the fewest outstanding
+// calls, taking turns among ties.
+type leastOutstandingBalancer struct {
+	next uint64
+}
+
+func (b *leastOutstandingBalancer) pick(endpoints []*Endpoint) *Endpoint {
+	start := atomic.AddUint64(&b.next, 1) - 1
+	picked := endpoints[start%uint64(len(endpoints))]
+
+	for i := uint64(1); i < uint64(len(endpoints)); i++ {
+		endpoint := endpoints[(start+i)%uint64(len(endpoints))]
+		if endpoint.Outstanding() < picked.Outstanding() {
+			picked = endpoint
+		}
+	}
+
+	return picked
+}
+
+// powerOfTwoChoicesBalancer - picks the endpoint with fewer outstanding calls of
+// 2 distinct random endpoints.
+type powerOfTwoChoicesBalancer struct{}
+
+func (b *powerOfTwoChoicesBalancer) pick(endpoints []*Endpoint) *Endpoint {
+	if len(endpoints) == 1 {
+		return endpoints[0]
+	}
+
+	//nolint:gosec // balancing does not need a secure random source
+	first, second := rand.Intn(len(endpoints)), rand.Intn(len(endpoints)-1)
+	if second >= first {
+		second++
+	}
+
+	if endpoints[second].Outstanding() < endpoints[first].Outstanding() {
+		return endpoints[second]
+	}
+
+	return endpoints[first]
+}
@@ -0,0 +1,96 @@
+package pool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testEndpoints - returns endpoints with outstanding calls.
+func testEndpoints(outstanding ...int64) []*Endpoint {
+	endpoints := make([]*Endpoint, 0, len(outstanding))
+
+	for i, count := range outstanding {
+		endpoints = append(endpoints, &Endpoint{
+			Address:     string(rune('a'+i)) + ":1",
+			outstanding: count,
+		})
+	}
+
+	return endpoints
+}
+
+// pickCounts - picks an endpoint n times with b, returning the number of picks
+// of each endpoint by address.
+func pickCounts(b balancer, endpoints []*Endpoint, n int) map[string]int {
+	counts := make(map[string]int, len(endpoints))
+
+	for i := 0; i < n; i++ {
+		counts[b.pick(endpoints).Address]++
+	}
+
+	return counts
+}
+
+func TestBalancerPolicyString(t *testing.T) {
+	assert.Equal(t, "round_robin", RoundRobin.String())
+	assert.Equal(t, "least_outstanding", LeastOutstanding.String())
+	assert.Equal(t, "power_of_two_choices", PowerOfTwoChoices.String())
+}
+
+func TestRoundRobinBalancer(t *testing.T) {
+	endpoints := testEndpoints(5, 0, 0)
+	b := newBalancer(RoundRobin)
+
+	for i := 0; i < 6; i++ {
+		assert.Same(t, endpoints[i%3], b.pick(endpoints))
+	}
+
+	assert.Equal(t, map[string]int{"a:1": 100, "b:1": 100, "c:1": 100}, pickCounts(b, endpoints, 300))
+}
+
+func TestLeastOutstandingBalancer(t *testing.T) {
+	endpoints := testEndpoints(3, 0, 1)
+	b := newBalancer(LeastOutstanding)
+
+	assert.Equal(t, map[string]int{"b:1": 30}, pickCounts(b, endpoints, 30))
+
+	endpoints = testEndpoints(0, 0, 0)
+	assert.Equal(t, map[string]int{"a:1": 100, "b:1": 100, "c:1": 100}, pickCounts(b, endpoints, 300))
+
+	endpoints = testEndpoints(2, 0, 0)
+
+	for i := 0; i < 8; i++ {
+		endpoint := b.pick(endpoints)
+		endpoint.outstanding++
+	}
+
+	outstanding := []int64{}
+	for _, endpoint := range endpoints {
+		outstanding = append(outstanding, endpoint.Outstanding())
+	}
+
+	assert.ElementsMatch(t, []int64{3, 3, 4}, outstanding)
+}
+
+func TestPowerOfTwoChoicesBalancer(t *testing.T) {
+	b := newBalancer(PowerOfTwoChoices)
+
+	endpoints := testEndpoints(4)
+	assert.Equal(t, map[string]int{"a:1": 10}, pickCounts(b, endpoints, 10))
+
+	endpoints = testEndpoints(10, 0, 0, 0)
+	counts := pickCounts(b, endpoints, 1000)
+
+	assert.Zero(t, counts["a:1"])
+
+	for _, address := range []string{"b:1", "c:1", "d:1"} {
+		assert.Greater(t, counts[address], 200, address)
+	}
+
+	endpoints = testEndpoints(10, 5, 0)
+	counts = pickCounts(b, endpoints, 1000)
+
+	assert.Zero(t, counts["a:1"])
+	assert.Greater(t, counts["c:1"], counts["b:1"])
+}
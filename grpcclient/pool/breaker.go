@@ -0,0 +1,307 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/twothicc/common-go/commonerror"
+	"github.com/twothicc/common-go/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+// BreakerState - state of a circuit breaker.
+type BreakerState int32
+
+const (
+	BreakerClosed   BreakerState = iota // calls are allowed
+	BreakerOpen                         // calls fail fast with ErrCodeCircuitOpen
+	BreakerHalfOpen                     // a limited number of trial calls are allowed
+)
+
+var breakerStateNames = map[BreakerState]string{
+	BreakerClosed:   "closed",
+	BreakerOpen:     "open",
+	BreakerHalfOpen: "half-open",
+}
+
+// String - returns the name of BreakerState.
+func (s BreakerState) String() string {
+	return breakerStateNames[s]
+}
+
+// BreakerConfigs - configures the circuit breaker of a server.
+//
+// The breaker opens when ConsecutiveFailures calls fail in a row, or when the
+// failure rate of the calls within Window reaches FailureRateThreshold after at
+// least MinRequests calls. Either threshold is disabled if 0.
+//
+// After OpenTimeout, the breaker becomes half-open and allows HalfOpenMaxRequests
+// trial calls, closing if all of them succeed and opening again on any failure.
+// Trials whose results are not reported within OpenTimeout, e.g. of abandoned
+// streams, are released so that new trial calls are allowed.
+type BreakerConfigs struct {
+	// OnStateChange is called with the breaker locked on every state change, e.g.
+	// to export the state as a metric. It must not call the breaker.
+	OnStateChange        func(server string, from, to BreakerState)
+	FailureGRPCCodes     []codes.Code // grpc codes of errors counted as failures
+	Window               time.Duration
+	OpenTimeout          time.Duration
+	FailureRateThreshold float64
+	ConsecutiveFailures  int
+	MinRequests          int
+	HalfOpenMaxRequests  int
+}
+
+// GetDefaultBreakerConfigs - returns the default circuit breaker configs.
+func GetDefaultBreakerConfigs() *BreakerConfigs {
+	return &BreakerConfigs{
+		FailureGRPCCodes:     defaultFailureGRPCCodes(),
+		Window:               DEFAULT_BREAKER_WINDOW,
+		OpenTimeout:          DEFAULT_BREAKER_OPEN_TIMEOUT,
+		FailureRateThreshold: DEFAULT_BREAKER_FAILURE_RATE_THRESHOLD,
+		ConsecutiveFailures:  DEFAULT_BREAKER_CONSECUTIVE_FAILURES,
+		MinRequests:          DEFAULT_BREAKER_MIN_REQUESTS,
+		HalfOpenMaxRequests:  DEFAULT_BREAKER_HALF_OPEN_MAX_REQUESTS,
+	}
+}
+
+// defaultFailureGRPCCodes - returns the grpc codes of errors counted as failures
+// of a server by default.
+func defaultFailureGRPCCodes() []codes.Code {
+	return []codes.Code{
+		codes.Unavailable,
+		codes.DeadlineExceeded,
+		codes.ResourceExhausted,
+		codes.Internal,
+		codes.Unknown,
+	}
+}
+
+// CircuitBreaker - circuit breaker of calls to a server.
+//
+// A nil CircuitBreaker is disabled and allows every call.
+type CircuitBreaker struct {
+	configs             *BreakerConfigs
+	windowStart         time.Time
+	openUntil           time.Time
+	server              string
+	generation          uint64
+	requests            int
+	failures            int
+	consecutiveFailures int
+	halfOpenRequests    int
+	halfOpenSuccesses   int
+	mu                  sync.Mutex
+	state               BreakerState
+}
+
+// NewCircuitBreaker - creates a closed circuit breaker of calls to server, nil if
+// configs is nil.
+func NewCircuitBreaker(server string, configs *BreakerConfigs) *CircuitBreaker {
+	if configs == nil {
+		return nil
+	}
+
+	return &CircuitBreaker{
+		configs:     configs,
+		server:      server,
+		windowStart: time.Now(),
+		state:       BreakerClosed,
+	}
+}
+
+// State - returns the current state of the breaker.
+func (cb *CircuitBreaker) State() BreakerState {
+	if cb == nil {
+		return BreakerClosed
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refresh(time.Now())
+
+	return cb.state
+}
+
+// Allow - checks if a call to the server may be made, returning a common error
+// of ErrCodeCircuitOpen if not.
+//
+// report must be called with the result of the allowed call.
+func (cb *CircuitBreaker) Allow() (report func(err error), err error) {
+	if cb == nil {
+		return func(err error) {}, nil
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refresh(time.Now())
+
+	switch cb.state {
+	case BreakerOpen:
+		return nil, cb.circuitOpenError()
+	case BreakerHalfOpen:
+		if cb.halfOpenRequests >= cb.configs.HalfOpenMaxRequests {
+			return nil, cb.circuitOpenError()
+		}
+
+		cb.halfOpenRequests++
+	case BreakerClosed:
+	}
+
+	generation := cb.generation
+
+	return func(err error) {
+		cb.report(generation, err)
+	}, nil
+}
+
+// report - records the result of a call allowed in generation. Results of calls
+// allowed before the last state change are ignored.
+func (cb *CircuitBreaker) report(generation uint64, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := time.Now()
+	cb.refresh(now)
+
+	if generation != cb.generation {
+		return
+	}
+
+	failed := cb.isFailure(err)
+
+	switch cb.state {
+	case BreakerClosed:
+		cb.requests++
+
+		if !failed {
+			cb.consecutiveFailures = 0
+
+			return
+		}
+
+		cb.failures++
+		cb.consecutiveFailures++
+
+		if cb.shouldTrip() {
+			cb.setState(BreakerOpen, now)
+		}
+	case BreakerHalfOpen:
+		if failed {
+			cb.setState(BreakerOpen, now)
+
+			return
+		}
+
+		cb.halfOpenSuccesses++
+
+		if cb.halfOpenSuccesses >= cb.configs.HalfOpenMaxRequests {
+			cb.setState(BreakerClosed, now)
+		}
+	case BreakerOpen:
+	}
+}
+
+// shouldTrip - checks if the failures of the current window exceed the thresholds.
+func (cb *CircuitBreaker) shouldTrip() bool {
+	if cb.configs.ConsecutiveFailures > 0 && cb.consecutiveFailures >= cb.configs.ConsecutiveFailures {
+		return true
+	}
+
+	return cb.configs.FailureRateThreshold > 0 &&
+		cb.requests >= cb.configs.MinRequests &&
+		float64(cb.failures)/float64(cb.requests) >= cb.configs.FailureRateThreshold
+}
+
+// isFailure - checks if err counts as a failure of the server.
+func (cb *CircuitBreaker) isFailure(err error) bool {
+	return isFailure(err, cb.configs.FailureGRPCCodes)
+}
+
+// isFailure - checks if err is of any of failureCodes.
+func isFailure(err error, failureCodes []codes.Code) bool {
+	if err == nil {
+		return false
+	}
+
+	grpcCode := commonerror.Convert(err).GRPCCode()
+
+	for _, failureCode := range failureCodes {
+		if grpcCode == failureCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// refresh - moves an open breaker to half-open after OpenTimeout, releases the
+// unreported trials of a half-open breaker after OpenTimeout, and starts a new
+// window of a closed breaker after Window.
+func (cb *CircuitBreaker) refresh(now time.Time) {
+	switch cb.state {
+	case BreakerOpen:
+		if !now.Before(cb.openUntil) {
+			cb.setState(BreakerHalfOpen, now)
+		}
+	case BreakerClosed:
+		if cb.configs.Window > 0 && now.Sub(cb.windowStart) >= cb.configs.Window {
+			cb.requests, cb.failures = 0, 0
+			cb.windowStart = now
+		}
+	case BreakerHalfOpen:
+		if cb.halfOpenRequests > cb.halfOpenSuccesses && now.Sub(cb.windowStart) >= cb.configs.OpenTimeout {
+			cb.releaseTrials(now)
+		}
+	}
+}
+
+// releaseTrials - allows new trial calls of a half-open breaker, ignoring the
+// results of the trials allowed so far.
+func (cb *CircuitBreaker) releaseTrials(now time.Time) {
+	cb.generation++
+	cb.halfOpenRequests, cb.halfOpenSuccesses = 0, 0
+	cb.windowStart = now
+
+	logger.WithContext(context.Background()).Warn("circuit breaker trials released",
+		zap.String("server", cb.server),
+	)
+}
+
+// setState - changes the state of the breaker, resetting its counts.
+func (cb *CircuitBreaker) setState(state BreakerState, now time.Time) {
+	from := cb.state
+
+	cb.state = state
+	cb.generation++
+	cb.requests, cb.failures, cb.consecutiveFailures = 0, 0, 0
+	cb.halfOpenRequests, cb.halfOpenSuccesses = 0, 0
+	cb.windowStart = now
+
+	if state == BreakerOpen {
+		cb.openUntil = now.Add(cb.configs.OpenTimeout)
+	}
+
+	logger.WithContext(context.Background()).Warn("circuit breaker state changed",
+		zap.String("server", cb.server),
+		zap.Stringer("from", from),
+		zap.Stringer("to", state),
+	)
+
+	if cb.configs.OnStateChange != nil {
+		cb.configs.OnStateChange(cb.server, from, state)
+	}
+}
+
+// circuitOpenError - returns the common error of calls rejected by the breaker.
+func (cb *CircuitBreaker) circuitOpenError() error {
+	return commonerror.New(
+		commonerror.ErrCodeCircuitOpen,
+		fmt.Sprintf("%s, server = %s", commonerror.ErrMsgCircuitOpen, cb.server),
+	)
+}
@@ -0,0 +1,242 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testBreakerServer = "localhost:8080"
+
+var errUnavailable = status.Error(codes.Unavailable, "unavailable")
+
+// stateChange - state change of a circuit breaker.
+type stateChange struct {
+	from BreakerState
+	to   BreakerState
+}
+
+// newTestBreaker - creates a breaker of configs, recording its state changes.
+func newTestBreaker(configs *BreakerConfigs) (*CircuitBreaker, *[]stateChange) {
+	var changes []stateChange
+
+	configs.FailureGRPCCodes = GetDefaultBreakerConfigs().FailureGRPCCodes
+	configs.OnStateChange = func(server string, from, to BreakerState) {
+		changes = append(changes, stateChange{from: from, to: to})
+	}
+
+	return NewCircuitBreaker(testBreakerServer, configs), &changes
+}
+
+// reportCalls - makes calls allowed by cb failing with errs, nil for success.
+func reportCalls(t *testing.T, cb *CircuitBreaker, errs ...error) {
+	t.Helper()
+
+	for _, err := range errs {
+		report, allowErr := cb.Allow()
+		require.NoError(t, allowErr)
+		report(err)
+	}
+}
+
+// assertCircuitOpen - asserts that cb rejects calls.
+func assertCircuitOpen(t *testing.T, cb *CircuitBreaker) {
+	t.Helper()
+
+	_, err := cb.Allow()
+	require.Error(t, err)
+	assert.Equal(t, int32(commonerror.ErrCodeCircuitOpen), commonerror.Convert(err).Code())
+}
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	cb, changes := newTestBreaker(&BreakerConfigs{
+		OpenTimeout:         time.Minute,
+		ConsecutiveFailures: 3,
+	})
+
+	reportCalls(t, cb, errUnavailable, errUnavailable, nil, errUnavailable, errUnavailable)
+	assert.Equal(t, BreakerClosed, cb.State())
+
+	reportCalls(t, cb, status.Error(codes.NotFound, "not found"), errUnavailable, errUnavailable)
+	assert.Equal(t, BreakerClosed, cb.State(), "errors other than FailureGRPCCodes are not failures")
+
+	reportCalls(t, cb, errUnavailable)
+	assert.Equal(t, BreakerOpen, cb.State())
+	assert.Equal(t, []stateChange{{from: BreakerClosed, to: BreakerOpen}}, *changes)
+
+	assertCircuitOpen(t, cb)
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	cb, _ := newTestBreaker(&BreakerConfigs{
+		Window:               time.Minute,
+		OpenTimeout:          time.Minute,
+		FailureRateThreshold: 0.5,
+		MinRequests:          4,
+	})
+
+	reportCalls(t, cb, errUnavailable, errUnavailable, errUnavailable)
+	assert.Equal(t, BreakerClosed, cb.State(), "below MinRequests")
+
+	cb, _ = newTestBreaker(&BreakerConfigs{
+		Window:               time.Minute,
+		OpenTimeout:          time.Minute,
+		FailureRateThreshold: 0.5,
+		MinRequests:          4,
+	})
+
+	reportCalls(t, cb, nil, nil, nil, errUnavailable)
+	assert.Equal(t, BreakerClosed, cb.State(), "below FailureRateThreshold")
+
+	reportCalls(t, cb, errUnavailable)
+	assert.Equal(t, BreakerClosed, cb.State(), "below FailureRateThreshold")
+
+	reportCalls(t, cb, errUnavailable)
+	assert.Equal(t, BreakerOpen, cb.State())
+}
+
+func TestCircuitBreakerWindowReset(t *testing.T) {
+	cb, _ := newTestBreaker(&BreakerConfigs{
+		Window:               50 * time.Millisecond,
+		OpenTimeout:          time.Minute,
+		FailureRateThreshold: 0.5,
+		MinRequests:          4,
+	})
+
+	reportCalls(t, cb, errUnavailable, errUnavailable, errUnavailable)
+	time.Sleep(60 * time.Millisecond)
+
+	reportCalls(t, cb, errUnavailable)
+	assert.Equal(t, BreakerClosed, cb.State())
+
+	reportCalls(t, cb, errUnavailable, errUnavailable, errUnavailable)
+	assert.Equal(t, BreakerOpen, cb.State())
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	cb, changes := newTestBreaker(&BreakerConfigs{
+		OpenTimeout:         30 * time.Millisecond,
+		ConsecutiveFailures: 1,
+		HalfOpenMaxRequests: 2,
+	})
+
+	reportCalls(t, cb, errUnavailable)
+	assert.Equal(t, BreakerOpen, cb.State())
+
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, BreakerHalfOpen, cb.State())
+
+	firstReport, err := cb.Allow()
+	require.NoError(t, err)
+
+	secondReport, err := cb.Allow()
+	require.NoError(t, err)
+
+	assertCircuitOpen(t, cb)
+
+	firstReport(nil)
+	assert.Equal(t, BreakerHalfOpen, cb.State())
+
+	secondReport(nil)
+	assert.Equal(t, BreakerClosed, cb.State())
+
+	assert.Equal(t, []stateChange{
+		{from: BreakerClosed, to: BreakerOpen},
+		{from: BreakerOpen, to: BreakerHalfOpen},
+		{from: BreakerHalfOpen, to: BreakerClosed},
+	}, *changes)
+}
+
+func TestCircuitBreakerHalfOpenFailure(t *testing.T) {
+	cb, _ := newTestBreaker(&BreakerConfigs{
+		OpenTimeout:         30 * time.Millisecond,
+		ConsecutiveFailures: 1,
+		HalfOpenMaxRequests: 2,
+	})
+
+	reportCalls(t, cb, errUnavailable)
+	time.Sleep(40 * time.Millisecond)
+
+	reportCalls(t, cb, nil, errUnavailable)
+	assert.Equal(t, BreakerOpen, cb.State())
+	assertCircuitOpen(t, cb)
+}
+
+func TestCircuitBreakerStaleReports(t *testing.T) {
+	cb, _ := newTestBreaker(&BreakerConfigs{
+		OpenTimeout:         30 * time.Millisecond,
+		ConsecutiveFailures: 1,
+		HalfOpenMaxRequests: 1,
+	})
+
+	staleReport, err := cb.Allow()
+	require.NoError(t, err)
+
+	reportCalls(t, cb, errUnavailable)
+	time.Sleep(40 * time.Millisecond)
+	require.Equal(t, BreakerHalfOpen, cb.State())
+
+	staleReport(nil)
+	assert.Equal(t, BreakerHalfOpen, cb.State())
+
+	report, err := cb.Allow()
+	require.NoError(t, err)
+
+	staleReport(errUnavailable)
+	assert.Equal(t, BreakerHalfOpen, cb.State())
+
+	report(nil)
+	assert.Equal(t, BreakerClosed, cb.State())
+}
+
+func TestCircuitBreakerAbandonedTrial(t *testing.T) {
+	cb, changes := newTestBreaker(&BreakerConfigs{
+		OpenTimeout:         30 * time.Millisecond,
+		ConsecutiveFailures: 1,
+		HalfOpenMaxRequests: 1,
+	})
+
+	reportCalls(t, cb, errUnavailable)
+	time.Sleep(40 * time.Millisecond)
+
+	abandonedReport, err := cb.Allow()
+	require.NoError(t, err)
+	assertCircuitOpen(t, cb)
+
+	time.Sleep(40 * time.Millisecond)
+
+	report, err := cb.Allow()
+	require.NoError(t, err)
+
+	abandonedReport(errUnavailable)
+	assert.Equal(t, BreakerHalfOpen, cb.State())
+
+	report(nil)
+	assert.Equal(t, BreakerClosed, cb.State())
+
+	assert.Equal(t, []stateChange{
+		{from: BreakerClosed, to: BreakerOpen},
+		{from: BreakerOpen, to: BreakerHalfOpen},
+		{from: BreakerHalfOpen, to: BreakerClosed},
+	}, *changes)
+}
+
+func TestCircuitBreakerNil(t *testing.T) {
+	cb := NewCircuitBreaker(testBreakerServer, nil)
+	require.Nil(t, cb)
+
+	assert.Equal(t, BreakerClosed, cb.State())
+
+	for i := 0; i < 10; i++ {
+		report, err := cb.Allow()
+		require.NoError(t, err)
+		report(errUnavailable)
+	}
+
+	assert.Equal(t, BreakerClosed, cb.State())
+}
@@ -1,8 +1,13 @@
 package pool
 
-import "time"
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
 
 type ConnConfigs struct {
+	Breaker         *BreakerConfigs // circuit breaker of the server, nil to disable
 	IdleTimeout     time.Duration
 	CreateTimeout   time.Duration // timeout for establishing connection
 	MaxLifeDuration time.Duration
@@ -28,6 +33,7 @@ func GetConnConfigs(
 		InitConn:        init,
 		MaxConn:         capacity,
 		EnableTLS:       enableTLS,
+		Breaker:         GetDefaultBreakerConfigs(),
 	}
 }
 
@@ -39,6 +45,7 @@ func GetDefaultConnConfigs() *ConnConfigs {
 		InitConn:        DEFAULT_INIT_CONN,
 		MaxConn:         DEFAULT_MAX_CONN,
 		EnableTLS:       DEFAULT_ENABLE_TLS,
+		Breaker:         GetDefaultBreakerConfigs(),
 	}
 }
 
@@ -66,3 +73,64 @@ func GetDefaultConnPoolConfigs(
 		ConnConfigs: GetDefaultConnConfigs(),
 	}
 }
+
+// SetBreakerConfigs - sets the circuit breaker configs of the server, nil to
+// disable the circuit breaker.
+func (c *ConnPoolConfigs) SetBreakerConfigs(breakerConfigs *BreakerConfigs) *ConnPoolConfigs {
+	connConfigs := *c.ConnConfigs
+	connConfigs.Breaker = breakerConfigs
+	c.ConnConfigs = &connConfigs
+
+	return c
+}
+
+// ServiceConfigs - configures a logical service served by multiple endpoints.
+//
+// The endpoints are resolved by Resolver every ResolveInterval, 0 to resolve them
+// only once. Endpoints of a StaticResolver are always resolved only once.
+//
+// An endpoint is ejected for EjectionCoolDown after EjectionFailures consecutive
+// calls to it fail, 0 to disable ejection. Calls are balanced among endpoints
+// that are not ejected, or among all endpoints if all of them are ejected.
+type ServiceConfigs struct {
+	*ConnConfigs                  // configs of the connection pools of endpoints, nil for the default
+	Resolver         Resolver     // resolver of the <domain>:<port> of endpoints
+	FailureGRPCCodes []codes.Code // grpc codes of errors counted as failures
+	Name             string
+	ResolveInterval  time.Duration
+	EjectionCoolDown time.Duration
+	EjectionFailures int
+	Balancer         BalancerPolicy
+}
+
+func GetServiceConfigs(
+	name string,
+	balancer BalancerPolicy,
+	connConfigs *ConnConfigs,
+	endpoints ...string,
+) *ServiceConfigs {
+	return &ServiceConfigs{
+		ConnConfigs:      connConfigs,
+		Resolver:         NewStaticResolver(endpoints...),
+		FailureGRPCCodes: defaultFailureGRPCCodes(),
+		Name:             name,
+		ResolveInterval:  DEFAULT_RESOLVE_INTERVAL,
+		EjectionCoolDown: DEFAULT_EJECTION_COOL_DOWN,
+		EjectionFailures: DEFAULT_EJECTION_FAILURES,
+		Balancer:         balancer,
+	}
+}
+
+func GetDefaultServiceConfigs(
+	name string,
+	endpoints ...string,
+) *ServiceConfigs {
+	return GetServiceConfigs(name, RoundRobin, GetDefaultConnConfigs(), endpoints...)
+}
+
+// SetResolver - sets the resolver of the endpoints of the service.
+func (c *ServiceConfigs) SetResolver(resolver Resolver) *ServiceConfigs {
+	c.Resolver = resolver
+
+	return c
+}
@@ -10,3 +10,18 @@ const (
 	DEFAULT_MAX_CONN          = 5
 	DEFAULT_ENABLE_TLS        = false
 )
+
+const (
+	DEFAULT_BREAKER_WINDOW                 = 10 * time.Second
+	DEFAULT_BREAKER_OPEN_TIMEOUT           = 5 * time.Second
+	DEFAULT_BREAKER_FAILURE_RATE_THRESHOLD = 0.5
+	DEFAULT_BREAKER_CONSECUTIVE_FAILURES   = 5
+	DEFAULT_BREAKER_MIN_REQUESTS           = 20
+	DEFAULT_BREAKER_HALF_OPEN_MAX_REQUESTS = 1
+)
+
+const (
+	DEFAULT_EJECTION_FAILURES  = 5
+	DEFAULT_EJECTION_COOL_DOWN = 30 * time.Second
+	DEFAULT_RESOLVE_INTERVAL   = 30 * time.Second
+)
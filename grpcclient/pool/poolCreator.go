@@ -5,6 +5,7 @@ import (
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_pool "github.com/processout/grpc-go-pool"
+	"github.com/twothicc/common-go/commonerror"
 	"github.com/twothicc/common-go/logger"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -84,15 +85,29 @@ func PoolCreator(
 
 		selector.mu.Lock()
 
+		if selector.pools == nil {
+			selector.mu.Unlock()
+			pool.Close()
+
+			return commonerror.New(commonerror.ErrCodeServer, "pool selector closed")
+		}
+
 		existingPool, ok := selector.pools[configs.Server]
 		if !ok {
 			selector.pools[configs.Server] = pool
-		} else if ok {
-			if allowOverwrite {
-				logger.WithContext(ctx).Debug("overwriting connection pool", zap.String("server", configs.Server))
-				existingPool.Close()
-				selector.pools[configs.Server] = pool
+
+			// keeps the breaker lazily created with the same configs, e.g. by Breaker
+			if breaker := selector.breakers[configs.Server]; breaker == nil || breaker.configs != configs.Breaker {
+				selector.breakers[configs.Server] = NewCircuitBreaker(configs.Server, configs.Breaker)
 			}
+		} else if allowOverwrite {
+			logger.WithContext(ctx).Debug("overwriting connection pool", zap.String("server", configs.Server))
+			existingPool.Close()
+			selector.pools[configs.Server] = pool
+			selector.breakers[configs.Server] = NewCircuitBreaker(configs.Server, configs.Breaker)
+		} else {
+			// lost the race against a concurrent creation of the same pool
+			defer pool.Close()
 		}
 		selector.mu.Unlock()
 
@@ -15,6 +15,8 @@ import (
 // PoolSelector - selects a connection pool by server
 type PoolSelector struct {
 	pools                           map[string]*grpc_pool.Pool
+	breakers                        map[string]*CircuitBreaker
+	services                        map[string]*service
 	defaultConnConfigs              *ConnConfigs
 	defaultUnaryClientInterceptors  []grpc.UnaryClientInterceptor
 	defaultStreamClientInterceptors []grpc.StreamClientInterceptor
@@ -40,6 +42,8 @@ func NewPoolSelector(
 ) *PoolSelector {
 	selector := &PoolSelector{
 		pools:                           make(map[string]*grpc_pool.Pool),
+		breakers:                        make(map[string]*CircuitBreaker),
+		services:                        make(map[string]*service),
 		defaultConnConfigs:              GetDefaultConnConfigs(),
 		defaultUnaryClientInterceptors:  defaultUnaryClientInterceptors,
 		defaultStreamClientInterceptors: defaultStreamClientInterceptors,
@@ -61,6 +65,10 @@ func (ps *PoolSelector) Close() {
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
 
+	for _, service := range ps.services {
+		service.stop()
+	}
+
 	for _, pool := range ps.pools {
 		pool.Close()
 	}
@@ -124,9 +132,6 @@ func (ps *PoolSelector) SetPool(
 	extraStreamClientInterceptors []grpc.StreamClientInterceptor,
 	allowOverride bool,
 ) error {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
 	return PoolCreator(
 		configs,
 		extraUnaryClientInterceptors,
@@ -138,19 +143,121 @@ func (ps *PoolSelector) SetPool(
 func (ps *PoolSelector) SetDefaultConnConfigs(
 	connConfigs *ConnConfigs,
 ) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
 	ps.defaultConnConfigs = connConfigs
 }
 
+// getDefaultConnConfigs - gets this PoolSelector's default connection configs.
+func (ps *PoolSelector) getDefaultConnConfigs() *ConnConfigs {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	return ps.defaultConnConfigs
+}
+
+// Breaker - returns the circuit breaker of server, creating one with this
+// PoolSelector's default connection configs if it does not exist.
+//
+// Returns nil if the circuit breaker of server is disabled.
+func (ps *PoolSelector) Breaker(server string) *CircuitBreaker {
+	ps.mu.RLock()
+	breaker, ok := ps.breakers[server]
+	ps.mu.RUnlock()
+
+	if ok {
+		return breaker
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if breaker, ok = ps.breakers[server]; !ok {
+		breaker = NewCircuitBreaker(server, ps.defaultConnConfigs.Breaker)
+		ps.breakers[server] = breaker
+	}
+
+	return breaker
+}
+
+// BreakerStates - returns the states of the circuit breakers by server, e.g. to
+// export as metrics.
+func (ps *PoolSelector) BreakerStates() map[string]BreakerState {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	states := make(map[string]BreakerState, len(ps.breakers))
+
+	for server, breaker := range ps.breakers {
+		if breaker != nil {
+			states[server] = breaker.State()
+		}
+	}
+
+	return states
+}
+
+// Pick - picks the endpoint to call if server is the name of a logical service,
+// returning the address of the endpoint and a func that must be called with the
+// result of the call, so that outstanding calls are tracked and failing
+// endpoints are ejected.
+//
+// excluded endpoints, e.g. ones already being called, are not picked unless all
+// endpoints are excluded.
+//
+// Returns server itself if it is not the name of a logical service.
+func (ps *PoolSelector) Pick(
+	server string,
+	excluded ...string,
+) (address string, report func(err error), err error) {
+	ps.mu.RLock()
+	service := ps.services[server]
+	ps.mu.RUnlock()
+
+	if service == nil {
+		return server, func(err error) {}, nil
+	}
+
+	endpoint, report, err := service.pick(excluded)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return endpoint.Address, report, nil
+}
+
+// ServiceEndpoints - returns the endpoints of the logical service, nil if it does
+// not exist.
+func (ps *PoolSelector) ServiceEndpoints(name string) []*Endpoint {
+	ps.mu.RLock()
+	service := ps.services[name]
+	ps.mu.RUnlock()
+
+	if service == nil {
+		return nil
+	}
+
+	service.mu.RLock()
+	defer service.mu.RUnlock()
+
+	return append([]*Endpoint(nil), service.endpoints...)
+}
+
 // getDefaultConnPoolConfigs - gets a connection pool configs with this PoolSelector's
 // default connection configs.
 func (ps *PoolSelector) getDefaultConnPoolConfigs(server string) *ConnPoolConfigs {
-	return GetConnPoolConfigs(
+	defaultConnConfigs := ps.getDefaultConnConfigs()
+
+	connPoolConfigs := GetConnPoolConfigs(
 		server,
-		ps.defaultConnConfigs.IdleTimeout,
-		ps.defaultConnConfigs.CreateTimeout,
-		ps.defaultConnConfigs.MaxLifeDuration,
-		ps.defaultConnConfigs.InitConn,
-		ps.defaultConnConfigs.MaxConn,
-		ps.defaultConnConfigs.EnableTLS,
+		defaultConnConfigs.IdleTimeout,
+		defaultConnConfigs.CreateTimeout,
+		defaultConnConfigs.MaxLifeDuration,
+		defaultConnConfigs.InitConn,
+		defaultConnConfigs.MaxConn,
+		defaultConnConfigs.EnableTLS,
 	)
+
+	return connPoolConfigs.SetBreakerConfigs(defaultConnConfigs.Breaker)
 }
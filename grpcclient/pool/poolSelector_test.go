@@ -0,0 +1,140 @@
+package pool
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// startServer - starts a grpc server without services, returning its address.
+func startServer(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+// countingListener - listener counting its open connections.
+type countingListener struct {
+	net.Listener
+	open *int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	atomic.AddInt32(l.open, 1)
+
+	return &countingConn{Conn: conn, open: l.open}, nil
+}
+
+// countingConn - connection of a countingListener.
+type countingConn struct {
+	net.Conn
+	open *int32
+	once sync.Once
+}
+
+func (c *countingConn) Close() error {
+	c.once.Do(func() {
+		atomic.AddInt32(c.open, -1)
+	})
+
+	return c.Conn.Close()
+}
+
+func TestGetCreatesPool(t *testing.T) {
+	address := startServer(t)
+
+	selector := NewPoolSelector(context.Background(), nil, nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+
+	go func() {
+		conn, err := selector.Get(ctx, address, true)
+		if err == nil {
+			err = conn.Close()
+		}
+
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Get with createIfNotExist did not return")
+	}
+
+	defer selector.Close()
+
+	selector.mu.RLock()
+	defer selector.mu.RUnlock()
+
+	assert.Contains(t, selector.pools, address)
+}
+
+func TestSetPool(t *testing.T) {
+	address := startServer(t)
+
+	selector := NewPoolSelector(context.Background(), nil, nil, nil)
+	defer selector.Close()
+
+	configs := GetConnPoolConfigs(address, DEFAULT_IDLE_TIMEOUT, DEFAULT_CREATE_TIMEOUT, DEFAULT_MAX_LIFE_DURATION, 0, 1, false)
+	require.NoError(t, selector.SetPool(context.Background(), configs, nil, nil, false))
+
+	conn, err := selector.Get(context.Background(), address, false)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+func TestSetPoolClosesUnusedPool(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var open int32
+
+	server := grpc.NewServer()
+
+	go func() {
+		_ = server.Serve(&countingListener{Listener: lis, open: &open})
+	}()
+
+	defer server.Stop()
+
+	address := lis.Addr().String()
+
+	selector := NewPoolSelector(context.Background(), nil, nil, nil)
+	defer selector.Close()
+
+	configs := GetConnPoolConfigs(address, DEFAULT_IDLE_TIMEOUT, DEFAULT_CREATE_TIMEOUT, DEFAULT_MAX_LIFE_DURATION, 1, 1, false)
+	require.NoError(t, selector.SetPool(context.Background(), configs, nil, nil, false))
+	require.NoError(t, selector.SetPool(context.Background(), configs, nil, nil, false))
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&open) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
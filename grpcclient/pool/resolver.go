@@ -0,0 +1,154 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Resolver - resolves a logical service to the addresses of its endpoints.
+//
+// Resolve is called when the service is registered, and then every ResolveInterval
+// of the service, so that endpoints can be added and removed while running.
+type Resolver interface {
+	// Resolve - returns the current <domain>:<port> addresses of the endpoints.
+	Resolve(ctx context.Context) ([]string, error)
+}
+
+// StaticResolver - resolves to a fixed list of addresses.
+type StaticResolver struct {
+	addresses []string
+}
+
+// NewStaticResolver - creates a resolver of addresses.
+func NewStaticResolver(addresses ...string) *StaticResolver {
+	return &StaticResolver{
+		addresses: addresses,
+	}
+}
+
+// Resolve - returns the addresses of the resolver.
+func (r *StaticResolver) Resolve(ctx context.Context) ([]string, error) {
+	return append([]string(nil), r.addresses...), nil
+}
+
+// DNSSRVResolver - resolves to the targets of DNS SRV records, looked up as
+// _service._proto.name, e.g. _grpc._tcp.greeter.default.svc.cluster.local.
+type DNSSRVResolver struct {
+	resolver *net.Resolver
+	service  string
+	proto    string
+	name     string
+}
+
+// NewDNSSRVResolver - creates a resolver of the SRV records of service, proto and
+// name. If service and proto are empty, name is looked up directly.
+func NewDNSSRVResolver(service, proto, name string) *DNSSRVResolver {
+	return &DNSSRVResolver{
+		resolver: net.DefaultResolver,
+		service:  service,
+		proto:    proto,
+		name:     name,
+	}
+}
+
+// Resolve - looks up the SRV records, returning their targets sorted.
+func (r *DNSSRVResolver) Resolve(ctx context.Context) ([]string, error) {
+	_, records, err := r.resolver.LookupSRV(ctx, r.service, r.proto, r.name)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(records))
+
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		addresses = append(addresses, net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+	}
+
+	sort.Strings(addresses)
+
+	return addresses, nil
+}
+
+// PollingFileResolver - resolves to the addresses listed in a JSON or YAML file,
+// e.g.
+//
+//	{"endpoints": ["10.0.0.1:8080", "10.0.0.2:8080"]}
+//
+// The format is chosen by the extension of the file, .json, .yaml or .yml.
+//
+// The file is not watched. It is polled on every Resolve, i.e. every
+// ResolveInterval of the service, and read again only if its modification time
+// or size has changed, so changes take up to ResolveInterval to be picked up.
+type PollingFileResolver struct {
+	modTime   time.Time
+	path      string
+	addresses []string
+	size      int64
+	mu        sync.Mutex
+}
+
+// endpointsFile - contents of the file of a PollingFileResolver.
+type endpointsFile struct {
+	Endpoints []string `json:"endpoints" yaml:"endpoints"`
+}
+
+// NewPollingFileResolver - creates a resolver of the file at path.
+func NewPollingFileResolver(path string) *PollingFileResolver {
+	return &PollingFileResolver{
+		path: path,
+	}
+}
+
+// Resolve - returns the addresses listed in the file, reading it only if it has
+// changed since it was last read.
+func (r *PollingFileResolver) Resolve(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.addresses != nil && info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+		return append([]string(nil), r.addresses...), nil
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file endpointsFile
+
+	switch ext := filepath.Ext(r.path); ext {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		err = fmt.Errorf("unsupported endpoints file extension %q", ext)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.addresses = append([]string{}, file.Endpoints...)
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+
+	return append([]string(nil), r.addresses...), nil
+}
@@ -0,0 +1,75 @@
+package pool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticResolver(t *testing.T) {
+	resolver := NewStaticResolver("a:1", "b:2")
+
+	addresses, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a:1", "b:2"}, addresses)
+
+	addresses[0] = "changed:1"
+	addresses, _ = resolver.Resolve(context.Background())
+	assert.Equal(t, []string{"a:1", "b:2"}, addresses)
+}
+
+func TestFileResolver(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, contents := range map[string]string{
+		"endpoints.json": `{"endpoints": ["a:1", "b:2"]}`,
+		"endpoints.yaml": "endpoints:\n  - a:1\n  - b:2\n",
+		"endpoints.yml":  "endpoints: [a:1, b:2]\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+
+		addresses, err := NewPollingFileResolver(path).Resolve(context.Background())
+		require.NoError(t, err, name)
+		assert.Equal(t, []string{"a:1", "b:2"}, addresses, name)
+	}
+}
+
+func TestFileResolverChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"endpoints": ["a:1"]}`), 0o600))
+
+	resolver := NewPollingFileResolver(path)
+
+	addresses, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a:1"}, addresses)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"endpoints": ["b:2", "c:3"]}`), 0o600))
+
+	addresses, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b:2", "c:3"}, addresses)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"endpoints": [`), 0o600))
+
+	_, err = resolver.Resolve(context.Background())
+	assert.Error(t, err)
+}
+
+func TestFileResolverErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewPollingFileResolver(filepath.Join(dir, "missing.json")).Resolve(context.Background())
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "endpoints.txt")
+	require.NoError(t, os.WriteFile(path, []byte("a:1"), 0o600))
+
+	_, err = NewPollingFileResolver(path).Resolve(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,397 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/twothicc/common-go/commonerror"
+	"github.com/twothicc/common-go/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Endpoint - endpoint of a logical service.
+type Endpoint struct {
+	ejectedUntil        time.Time
+	Address             string
+	outstanding         int64
+	consecutiveFailures int
+	mu                  sync.Mutex
+}
+
+// Outstanding - returns the number of calls to the endpoint in progress.
+func (e *Endpoint) Outstanding() int64 {
+	return atomic.LoadInt64(&e.outstanding)
+}
+
+// Ejected - checks if the endpoint is ejected.
+func (e *Endpoint) Ejected() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return time.Now().Before(e.ejectedUntil)
+}
+
+// report - records the result of a call to the endpoint, ejecting it on repeated
+// failures.
+func (e *Endpoint) report(service string, configs *ServiceConfigs, err error) {
+	atomic.AddInt64(&e.outstanding, -1)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !isFailure(err, configs.FailureGRPCCodes) {
+		e.consecutiveFailures = 0
+
+		return
+	}
+
+	e.consecutiveFailures++
+
+	if configs.EjectionFailures <= 0 || e.consecutiveFailures < configs.EjectionFailures {
+		return
+	}
+
+	e.consecutiveFailures = 0
+	e.ejectedUntil = time.Now().Add(configs.EjectionCoolDown)
+
+	logger.WithContext(context.Background()).Warn("endpoint ejected",
+		zap.String("service", service),
+		zap.String("endpoint", e.Address),
+		zap.Duration("coolDown", configs.EjectionCoolDown),
+	)
+}
+
+// service - logical service served by multiple endpoints.
+type service struct {
+	configs    *ServiceConfigs
+	balancer   balancer
+	createPool func(ctx context.Context, address string, allowOverwrite bool) error
+	done       chan struct{}
+	endpoints  []*Endpoint
+	stopOnce   sync.Once
+	mu         sync.RWMutex
+}
+
+func newService(
+	configs *ServiceConfigs,
+	createPool func(ctx context.Context, address string, allowOverwrite bool) error,
+) *service {
+	return &service{
+		configs:    configs,
+		balancer:   newBalancer(configs.Balancer),
+		createPool: createPool,
+		done:       make(chan struct{}),
+	}
+}
+
+// pick - picks an endpoint for a call other than excluded ones if possible,
+// returning a func to report the result of the call with.
+func (s *service) pick(excluded []string) (endpoint *Endpoint, report func(err error), err error) {
+	s.mu.RLock()
+	endpoints := s.endpoints
+	s.mu.RUnlock()
+
+	if len(endpoints) == 0 {
+		return nil, nil, commonerror.New(
+			commonerror.ErrCodeUnavailable,
+			fmt.Sprintf("no endpoints, service = %s", s.configs.Name),
+		)
+	}
+
+	available := make([]*Endpoint, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		if !endpoint.Ejected() {
+			available = append(available, endpoint)
+		}
+	}
+
+	if len(available) == 0 {
+		available = endpoints
+	}
+
+	if len(excluded) > 0 {
+		available = excludeEndpoints(available, excluded)
+	}
+
+	endpoint = s.balancer.pick(available)
+	atomic.AddInt64(&endpoint.outstanding, 1)
+
+	return endpoint, func(err error) {
+		endpoint.report(s.configs.Name, s.configs, err)
+	}, nil
+}
+
+// excludeEndpoints - returns endpoints other than excluded ones, or endpoints if
+// all of them are excluded.
+func excludeEndpoints(endpoints []*Endpoint, excluded []string) []*Endpoint {
+	remaining := make([]*Endpoint, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		isExcluded := false
+
+		for _, address := range excluded {
+			if endpoint.Address == address {
+				isExcluded = true
+
+				break
+			}
+		}
+
+		if !isExcluded {
+			remaining = append(remaining, endpoint)
+		}
+	}
+
+	if len(remaining) == 0 {
+		return endpoints
+	}
+
+	return remaining
+}
+
+// setEndpoints - sets the endpoints of the service to addresses, keeping the
+// state of existing endpoints, and returns the addresses added and removed.
+func (s *service) setEndpoints(addresses []string) (added, removed []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := make(map[string]*Endpoint, len(s.endpoints))
+	for _, endpoint := range s.endpoints {
+		existing[endpoint.Address] = endpoint
+	}
+
+	endpoints := make([]*Endpoint, 0, len(addresses))
+	seen := make(map[string]bool, len(addresses))
+
+	for _, address := range addresses {
+		if seen[address] {
+			continue
+		}
+
+		seen[address] = true
+
+		endpoint, ok := existing[address]
+		if !ok {
+			endpoint = &Endpoint{Address: address}
+			added = append(added, address)
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	for _, endpoint := range s.endpoints {
+		if !seen[endpoint.Address] {
+			removed = append(removed, endpoint.Address)
+		}
+	}
+
+	s.endpoints = endpoints
+
+	return added, removed
+}
+
+// hasEndpoint - checks if address is an endpoint of the service.
+func (s *service) hasEndpoint(address string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, endpoint := range s.endpoints {
+		if endpoint.Address == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addresses - returns the addresses of the endpoints of the service.
+func (s *service) addresses() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	addresses := make([]string, 0, len(s.endpoints))
+	for _, endpoint := range s.endpoints {
+		addresses = append(addresses, endpoint.Address)
+	}
+
+	return addresses
+}
+
+// watched - checks if the endpoints of the service are to be resolved again every
+// ResolveInterval, which endpoints of a StaticResolver never need.
+func (s *service) watched() bool {
+	_, static := s.configs.Resolver.(*StaticResolver)
+
+	return !static && s.configs.ResolveInterval > 0
+}
+
+// stop - stops resolving the endpoints of the service.
+func (s *service) stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// ServiceCreator - creates a PoolCreatorFunc that handles registering a logical
+// service and creating connection pools for its endpoints to a PoolSelector.
+//
+// Calls to the service name are balanced among its endpoints, see PoolSelector.Pick.
+// The endpoints are resolved again every ResolveInterval, creating connection pools
+// of added endpoints and closing connection pools of removed endpoints. If the
+// service is registered again with allowOverwrite, the connection pools of the
+// endpoints it no longer has are closed.
+//
+// extraUnaryClientInterceptors and extraStreamClientInterceptors are set up for
+// the connection pools of all endpoints, as with PoolCreator.
+func ServiceCreator(
+	configs *ServiceConfigs,
+	extraUnaryClientInterceptors []grpc.UnaryClientInterceptor,
+	extraStreamClientInterceptors []grpc.StreamClientInterceptor,
+) PoolCreatorFunc {
+	return func(
+		ctx context.Context,
+		selector *PoolSelector,
+		allowOverwrite bool,
+	) error {
+		service := newService(configs, func(ctx context.Context, address string, allowOverwrite bool) error {
+			// resolved for each endpoint, so that default connection configs set after
+			// the service is registered, e.g. by NewClient, are used
+			connConfigs := configs.ConnConfigs
+			if connConfigs == nil {
+				connConfigs = selector.getDefaultConnConfigs()
+			}
+
+			return PoolCreator(
+				&ConnPoolConfigs{
+					ConnConfigs: connConfigs,
+					Server:      address,
+				},
+				extraUnaryClientInterceptors,
+				extraStreamClientInterceptors,
+			)(ctx, selector, allowOverwrite)
+		})
+
+		selector.mu.Lock()
+
+		existingService, ok := selector.services[configs.Name]
+		if ok && !allowOverwrite {
+			selector.mu.Unlock()
+
+			return nil
+		}
+
+		selector.services[configs.Name] = service
+		selector.mu.Unlock()
+
+		if ok {
+			existingService.stop()
+		}
+
+		err := selector.resolve(ctx, service, allowOverwrite)
+
+		if ok {
+			for _, address := range existingService.addresses() {
+				selector.retirePool(ctx, address)
+			}
+		}
+
+		if service.watched() {
+			go selector.watch(service)
+		}
+
+		return err
+	}
+}
+
+// resolve - resolves the endpoints of service, creating connection pools of added
+// endpoints and closing connection pools of removed endpoints.
+//
+// The endpoints are kept if they fail to be resolved.
+func (ps *PoolSelector) resolve(ctx context.Context, service *service, allowOverwrite bool) error {
+	addresses, err := service.configs.Resolver.Resolve(ctx)
+	if err != nil {
+		return fmt.Errorf("fail to resolve endpoints of service %s: %w", service.configs.Name, err)
+	}
+
+	added, removed := service.setEndpoints(addresses)
+
+	var errs []error
+
+	for _, address := range added {
+		if err := service.createPool(ctx, address, allowOverwrite); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, address := range removed {
+		ps.retirePool(ctx, address)
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		logger.WithContext(ctx).Info("service endpoints changed",
+			zap.String("service", service.configs.Name),
+			zap.Strings("added", added),
+			zap.Strings("removed", removed),
+		)
+	}
+
+	return errors.Join(errs...)
+}
+
+// watch - resolves the endpoints of service every ResolveInterval until it is
+// stopped.
+func (ps *PoolSelector) watch(service *service) {
+	ticker := time.NewTicker(service.configs.ResolveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-service.done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), service.configs.ResolveInterval)
+
+		if err := ps.resolve(ctx, service, false); err != nil {
+			logger.WithContext(ctx).Warn("fail to refresh service endpoints",
+				zap.String("service", service.configs.Name),
+				zap.Error(err),
+			)
+		}
+
+		cancel()
+	}
+}
+
+// retirePool - closes and removes the connection pool and circuit breaker of
+// address, unless it is still an endpoint of a service.
+//
+// Connections of the pool in use are closed once they are returned.
+func (ps *PoolSelector) retirePool(ctx context.Context, address string) {
+	ps.mu.Lock()
+
+	for _, service := range ps.services {
+		if service.hasEndpoint(address) {
+			ps.mu.Unlock()
+
+			return
+		}
+	}
+
+	pool := ps.pools[address]
+	delete(ps.pools, address)
+	delete(ps.breakers, address)
+	ps.mu.Unlock()
+
+	if pool != nil {
+		logger.WithContext(ctx).Debug("closing connection pool", zap.String("server", address))
+		pool.Close()
+	}
+}
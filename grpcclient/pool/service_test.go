@@ -0,0 +1,186 @@
+package pool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+)
+
+func TestServiceCreatorResolvesEndpoints(t *testing.T) {
+	selector := NewPoolSelector(context.Background(), nil, nil, []PoolCreatorFunc{
+		ServiceCreator(GetDefaultServiceConfigs("svc", "a:1", "b:2", "a:1"), nil, nil),
+	})
+	defer selector.Close()
+
+	assert.Equal(t, []string{"a:1", "b:2"}, endpointAddresses(selector.ServiceEndpoints("svc")))
+	assert.Contains(t, selector.pools, "a:1")
+	assert.Contains(t, selector.pools, "b:2")
+
+	picked := map[string]int{}
+
+	for i := 0; i < 4; i++ {
+		address, report, err := selector.Pick("svc")
+		require.NoError(t, err)
+		report(nil)
+
+		picked[address]++
+	}
+
+	assert.Equal(t, map[string]int{"a:1": 2, "b:2": 2}, picked)
+
+	address, _, err := selector.Pick("localhost:8080")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:8080", address)
+}
+
+func TestServiceCreatorWatchesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("endpoints: [a:1, b:2]\n"), 0o600))
+
+	configs := GetDefaultServiceConfigs("svc").SetResolver(NewPollingFileResolver(path))
+	configs.ResolveInterval = 10 * time.Millisecond
+
+	selector := NewPoolSelector(context.Background(), nil, nil, []PoolCreatorFunc{
+		ServiceCreator(configs, nil, nil),
+	})
+	defer selector.Close()
+
+	endpoint := selector.ServiceEndpoints("svc")[1]
+
+	require.NoError(t, os.WriteFile(path, []byte("endpoints: [b:2, c:3]\n"), 0o600))
+
+	assert.Eventually(t, func() bool {
+		endpoints := selector.ServiceEndpoints("svc")
+
+		return assert.ObjectsAreEqual([]string{"b:2", "c:3"}, endpointAddresses(endpoints)) &&
+			endpoints[0] == endpoint
+	}, time.Second, 10*time.Millisecond)
+
+	selector.mu.RLock()
+	defer selector.mu.RUnlock()
+
+	assert.NotContains(t, selector.pools, "a:1")
+	assert.NotContains(t, selector.breakers, "a:1")
+	assert.Contains(t, selector.pools, "c:3")
+}
+
+func TestServiceCreatorOverwriteRetiresEndpoints(t *testing.T) {
+	selector := NewPoolSelector(context.Background(), nil, nil, []PoolCreatorFunc{
+		ServiceCreator(GetDefaultServiceConfigs("svc", "a:1", "b:2"), nil, nil),
+	})
+	defer selector.Close()
+
+	configs := GetDefaultServiceConfigs("svc", "b:2", "c:3")
+	require.NoError(t, ServiceCreator(configs, nil, nil)(context.Background(), selector, true))
+
+	assert.Equal(t, []string{"b:2", "c:3"}, endpointAddresses(selector.ServiceEndpoints("svc")))
+
+	selector.mu.RLock()
+	defer selector.mu.RUnlock()
+
+	assert.NotContains(t, selector.pools, "a:1")
+	assert.NotContains(t, selector.breakers, "a:1")
+	assert.Contains(t, selector.pools, "b:2")
+	assert.Contains(t, selector.pools, "c:3")
+}
+
+func TestServiceWatched(t *testing.T) {
+	configs := GetDefaultServiceConfigs("svc", "a:1")
+	assert.False(t, newService(configs, nil).watched())
+
+	configs.SetResolver(NewPollingFileResolver("endpoints.yaml"))
+	assert.True(t, newService(configs, nil).watched())
+
+	configs.ResolveInterval = 0
+	assert.False(t, newService(configs, nil).watched())
+}
+
+func TestServiceEjectsFailingEndpoint(t *testing.T) {
+	configs := GetDefaultServiceConfigs("svc", "a:1", "b:2")
+	configs.EjectionFailures = 2
+	configs.EjectionCoolDown = 50 * time.Millisecond
+
+	selector := NewPoolSelector(context.Background(), nil, nil, []PoolCreatorFunc{
+		ServiceCreator(configs, nil, nil),
+	})
+	defer selector.Close()
+
+	failure := commonerror.New(commonerror.ErrCodeUnavailable, "down")
+
+	for i := 0; i < 4; i++ {
+		address, report, err := selector.Pick("svc")
+		require.NoError(t, err)
+
+		if address == "a:1" {
+			report(failure)
+		} else {
+			report(nil)
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		address, report, err := selector.Pick("svc")
+		require.NoError(t, err)
+		report(nil)
+
+		assert.Equal(t, "b:2", address)
+	}
+
+	time.Sleep(configs.EjectionCoolDown)
+
+	assert.False(t, selector.ServiceEndpoints("svc")[0].Ejected())
+}
+
+func TestPickExcludesEndpoints(t *testing.T) {
+	selector := NewPoolSelector(context.Background(), nil, nil, []PoolCreatorFunc{
+		ServiceCreator(GetServiceConfigs("svc", LeastOutstanding, nil, "a:1", "b:2"), nil, nil),
+	})
+	defer selector.Close()
+
+	for i := 0; i < 4; i++ {
+		address, report, err := selector.Pick("svc", "a:1")
+		require.NoError(t, err)
+		report(nil)
+
+		assert.Equal(t, "b:2", address)
+	}
+
+	address, report, err := selector.Pick("svc", "a:1", "b:2")
+	require.NoError(t, err)
+	report(nil)
+
+	assert.Contains(t, []string{"a:1", "b:2"}, address)
+}
+
+func endpointAddresses(endpoints []*Endpoint) []string {
+	addresses := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		addresses = append(addresses, endpoint.Address)
+	}
+
+	return addresses
+}
+
+func TestServiceCreatorDefaultConnConfigs(t *testing.T) {
+	selector := NewPoolSelector(context.Background(), nil, nil, nil)
+	defer selector.Close()
+
+	configs := GetServiceConfigs("svc", RoundRobin, nil, "a:1")
+	require.NoError(t, ServiceCreator(configs, nil, nil)(context.Background(), selector, false))
+
+	connConfigs := GetDefaultConnConfigs()
+	connConfigs.Breaker = nil
+	selector.SetDefaultConnConfigs(connConfigs)
+
+	configs.SetResolver(NewStaticResolver("a:1", "b:2"))
+	require.NoError(t, selector.resolve(context.Background(), selector.services["svc"], false))
+
+	assert.NotNil(t, selector.Breaker("a:1"))
+	assert.Nil(t, selector.Breaker("b:2"))
+}
@@ -0,0 +1,204 @@
+package grpcclient
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/twothicc/common-go/commonerror"
+	"github.com/twothicc/common-go/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// RetryPolicy - configures retries of failed calls.
+//
+// A failed call is retried while attempts remain if its common error is
+// retryable, i.e. its Retryability is not NonRetryable, its code is one of
+// RetryableCommonCodes, or its grpc code is one of RetryableGRPCCodes.
+//
+// Retries wait for an exponential backoff with jitter, or the retry delay of the
+// common error if longer, and are not made if the ctx deadline would pass first.
+type RetryPolicy struct {
+	RetryableCommonCodes []int32
+	RetryableGRPCCodes   []codes.Code
+	InitialBackoff       time.Duration // backoff before the first retry, DEFAULT_INITIAL_BACKOFF if not positive
+	MaxBackoff           time.Duration
+	PerAttemptTimeout    time.Duration // 0 for no timeout other than the ctx deadline
+	BackoffMultiplier    float64
+	Jitter               float64 // fraction of the backoff randomly subtracted, within [0, 1]
+	MaxAttempts          int     // including the first attempt
+}
+
+// GetDefaultRetryPolicy - returns a policy attempting calls once.
+func GetDefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialBackoff:    DEFAULT_INITIAL_BACKOFF,
+		MaxBackoff:        DEFAULT_MAX_BACKOFF,
+		BackoffMultiplier: DEFAULT_BACKOFF_MULTIPLIER,
+		Jitter:            DEFAULT_BACKOFF_JITTER,
+		MaxAttempts:       DEFAULT_MAX_ATTEMPTS,
+	}
+}
+
+// isRetryable - checks if a call failing with commonErr should be retried.
+func (p *RetryPolicy) isRetryable(commonErr commonerror.ICommonError) bool {
+	if commonErr.Retryability() != commonerror.NonRetryable {
+		return true
+	}
+
+	for _, code := range p.RetryableCommonCodes {
+		if commonErr.Code() == code {
+			return true
+		}
+	}
+
+	for _, grpcCode := range p.RetryableGRPCCodes {
+		if commonErr.GRPCCode() == grpcCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff - returns the backoff with jitter before retrying the attempt-th attempt.
+//
+// Errors without a retry delay are thus never retried immediately, even if the
+// policy sets no InitialBackoff.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initialBackoff := p.InitialBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = DEFAULT_INITIAL_BACKOFF
+	}
+
+	backoff := float64(initialBackoff) * math.Pow(math.Max(p.BackoffMultiplier, 1), float64(attempt-1))
+	if p.MaxBackoff > 0 {
+		backoff = math.Min(backoff, float64(p.MaxBackoff))
+	}
+
+	jitter := math.Min(math.Max(p.Jitter, 0), 1)
+	backoff -= backoff * jitter * rand.Float64() //nolint:gosec // jitter does not need a secure random source
+
+	return time.Duration(backoff)
+}
+
+// retryDelay - returns the delay before retrying the attempt-th attempt failing
+// with commonErr.
+func (p *RetryPolicy) retryDelay(attempt int, commonErr commonerror.ICommonError) time.Duration {
+	delay := p.backoff(attempt)
+	if retryDelay := commonErr.RetryDelay(); retryDelay > delay {
+		delay = retryDelay
+	}
+
+	return delay
+}
+
+// retry - calls attemptFunc until it succeeds or policy stops retrying, returning
+// the common error of the last attempt.
+//
+// The attempt number is tagged on the client span of each attempt and logged
+// with each retry.
+func retry(
+	ctx context.Context,
+	policy *RetryPolicy,
+	server, fullMethod string,
+	attemptFunc func(ctx context.Context) commonerror.ICommonError,
+) commonerror.ICommonError {
+	var commonErr commonerror.ICommonError
+
+	attempt := 1
+
+	for ; ; attempt++ {
+		attemptCtx := withClientSpanTags(ctx, opentracing.Tags{ATTEMPT_TAG: attempt})
+
+		commonErr = attemptFunc(attemptCtx)
+		if commonErr == nil {
+			return nil
+		}
+
+		if attempt >= policy.MaxAttempts || !policy.isRetryable(commonErr) || ctx.Err() != nil {
+			break
+		}
+
+		delay := policy.retryDelay(attempt, commonErr)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			break
+		}
+
+		logger.WithContext(ctx).Debug("retrying call",
+			zap.String("server", server),
+			zap.String("method", fullMethod),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(commonErr),
+		)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			break
+		}
+	}
+
+	if attempt > 1 {
+		logger.WithContext(ctx).Debug("call failed after retries",
+			zap.String("server", server),
+			zap.String("method", fullMethod),
+			zap.Int("attempts", attempt),
+			zap.Error(commonErr),
+		)
+	}
+
+	return commonErr
+}
+
+// StreamClientRetryInterceptor - retries establishing client streams according
+// to policy, nil for GetDefaultRetryPolicy.
+//
+// Only the creation of a stream is retried, errors of an established stream are
+// returned as is. PerAttemptTimeout is not applied, as it would bound the
+// lifetime of the stream.
+func StreamClientRetryInterceptor(policy *RetryPolicy) grpc.StreamClientInterceptor {
+	if policy == nil {
+		policy = GetDefaultRetryPolicy()
+	}
+
+	return streamClientRetryInterceptor(func(server, fullMethod string) *RetryPolicy {
+		return policy
+	})
+}
+
+// streamClientRetryInterceptor - same as StreamClientRetryInterceptor, with the
+// policy resolved by server and full method.
+func streamClientRetryInterceptor(
+	getRetryPolicy func(server, fullMethod string) *RetryPolicy,
+) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		var (
+			stream grpc.ClientStream
+			err    error
+		)
+
+		retry(ctx, getRetryPolicy(cc.Target(), method), cc.Target(), method,
+			func(attemptCtx context.Context) commonerror.ICommonError {
+				stream, err = streamer(attemptCtx, desc, cc, method, opts...)
+				if err != nil {
+					return commonerror.Convert(err)
+				}
+
+				return nil
+			},
+		)
+
+		return stream, err
+	}
+}
@@ -0,0 +1,283 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testServer = "localhost:8080"
+	testMethod = "/test.Svc/Get"
+)
+
+// testRetryPolicy - returns a policy of maxAttempts attempts with short backoffs.
+func testRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		InitialBackoff:    time.Millisecond,
+		MaxBackoff:        10 * time.Millisecond,
+		BackoffMultiplier: DEFAULT_BACKOFF_MULTIPLIER,
+		MaxAttempts:       maxAttempts,
+	}
+}
+
+// failingAttempts - returns an attempt func failing with commonErr until it has
+// been called succeedAfter times, and a pointer to its number of calls.
+func failingAttempts(
+	commonErr commonerror.ICommonError,
+	succeedAfter int,
+) (func(ctx context.Context) commonerror.ICommonError, *int) {
+	attempts := 0
+
+	return func(ctx context.Context) commonerror.ICommonError {
+		attempts++
+		if succeedAfter > 0 && attempts >= succeedAfter {
+			return nil
+		}
+
+		return commonErr
+	}, &attempts
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        time.Second,
+		BackoffMultiplier: 2,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, policy.backoff(4))
+	assert.Equal(t, time.Second, policy.backoff(5))
+
+	policy.MaxBackoff = 0
+	assert.Equal(t, 1600*time.Millisecond, policy.backoff(5))
+
+	policy.BackoffMultiplier = 0.5
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(3))
+
+	policy = &RetryPolicy{BackoffMultiplier: 2}
+	assert.Equal(t, DEFAULT_INITIAL_BACKOFF, policy.backoff(1))
+	assert.Equal(t, 2*DEFAULT_INITIAL_BACKOFF, policy.backoff(2))
+}
+
+func TestRetryPolicyBackoffJitter(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        time.Second,
+		BackoffMultiplier: 2,
+		Jitter:            0.2,
+	}
+
+	for i := 0; i < 100; i++ {
+		backoff := policy.backoff(2)
+		assert.GreaterOrEqual(t, backoff, 160*time.Millisecond)
+		assert.LessOrEqual(t, backoff, 200*time.Millisecond)
+
+		backoff = policy.backoff(5)
+		assert.GreaterOrEqual(t, backoff, 800*time.Millisecond)
+		assert.LessOrEqual(t, backoff, time.Second)
+	}
+
+	policy.Jitter = 2
+
+	for i := 0; i < 100; i++ {
+		backoff := policy.backoff(1)
+		assert.GreaterOrEqual(t, backoff, time.Duration(0))
+		assert.LessOrEqual(t, backoff, 100*time.Millisecond)
+	}
+
+	policy.Jitter = -1
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+}
+
+func TestRetryPolicyRetryDelay(t *testing.T) {
+	policy := testRetryPolicy(3)
+
+	commonErr := commonerror.NewWithDetails(commonerror.ErrCodeTimeout, "timeout", &commonerror.Details{
+		RetryInfo: &commonerror.RetryInfo{RetryDelay: 50 * time.Millisecond},
+	})
+	assert.Equal(t, 50*time.Millisecond, policy.retryDelay(1, commonErr))
+	assert.Equal(t, time.Millisecond, policy.retryDelay(1, commonerror.New(commonerror.ErrCodeUnavailable, "unavailable")))
+
+	policy.InitialBackoff = 100 * time.Millisecond
+	policy.MaxBackoff = 0
+	assert.Equal(t, 100*time.Millisecond, policy.retryDelay(1, commonErr))
+}
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	policy := testRetryPolicy(3)
+
+	assert.True(t, policy.isRetryable(commonerror.New(commonerror.ErrCodeTimeout, "timeout")))
+	assert.False(t, policy.isRetryable(commonerror.New(commonerror.ErrCodeNotFound, "not found")))
+
+	policy.RetryableCommonCodes = []int32{commonerror.ErrCodeNotFound}
+	assert.True(t, policy.isRetryable(commonerror.New(commonerror.ErrCodeNotFound, "not found")))
+
+	policy.RetryableGRPCCodes = []codes.Code{codes.InvalidArgument}
+	assert.True(t, policy.isRetryable(commonerror.New(commonerror.ErrCodeInvalidArgument, "invalid")))
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	timeoutErr := commonerror.New(commonerror.ErrCodeTimeout, "timeout")
+
+	attemptFunc, attempts := failingAttempts(timeoutErr, 0)
+	assert.Equal(t, timeoutErr, retry(context.Background(), testRetryPolicy(3), testServer, testMethod, attemptFunc))
+	assert.Equal(t, 3, *attempts)
+
+	attemptFunc, attempts = failingAttempts(timeoutErr, 2)
+	assert.Nil(t, retry(context.Background(), testRetryPolicy(3), testServer, testMethod, attemptFunc))
+	assert.Equal(t, 2, *attempts)
+
+	attemptFunc, attempts = failingAttempts(timeoutErr, 0)
+	assert.Equal(t, timeoutErr, retry(context.Background(), testRetryPolicy(0), testServer, testMethod, attemptFunc))
+	assert.Equal(t, 1, *attempts)
+}
+
+func TestRetryNonRetryable(t *testing.T) {
+	notFoundErr := commonerror.New(commonerror.ErrCodeNotFound, "not found")
+
+	attemptFunc, attempts := failingAttempts(notFoundErr, 0)
+	assert.Equal(t, notFoundErr, retry(context.Background(), testRetryPolicy(3), testServer, testMethod, attemptFunc))
+	assert.Equal(t, 1, *attempts)
+}
+
+func TestRetryDelayOverridesBackoff(t *testing.T) {
+	commonErr := commonerror.NewWithDetails(commonerror.ErrCodeTimeout, "timeout", &commonerror.Details{
+		RetryInfo: &commonerror.RetryInfo{RetryDelay: 50 * time.Millisecond},
+	})
+
+	attemptFunc, attempts := failingAttempts(commonErr, 2)
+	start := time.Now()
+
+	assert.Nil(t, retry(context.Background(), testRetryPolicy(2), testServer, testMethod, attemptFunc))
+	assert.Equal(t, 2, *attempts)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestRetryStopsBeforeDeadline(t *testing.T) {
+	commonErr := commonerror.NewWithDetails(commonerror.ErrCodeTimeout, "timeout", &commonerror.Details{
+		RetryInfo: &commonerror.RetryInfo{RetryDelay: time.Second},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	attemptFunc, attempts := failingAttempts(commonErr, 0)
+	start := time.Now()
+
+	assert.Equal(t, commonErr, retry(ctx, testRetryPolicy(5), testServer, testMethod, attemptFunc))
+	assert.Equal(t, 1, *attempts)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	attemptFunc := func(ctx context.Context) commonerror.ICommonError {
+		attempts++
+		cancel()
+
+		return commonerror.New(commonerror.ErrCodeTimeout, "timeout")
+	}
+
+	assert.NotNil(t, retry(ctx, testRetryPolicy(5), testServer, testMethod, attemptFunc))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestGetRetryPolicy(t *testing.T) {
+	configs := GetDefaultClientConfigs("test", true)
+	assert.Equal(t, GetDefaultRetryPolicy(), configs.getRetryPolicy(testServer, testMethod))
+
+	defaultPolicy, serverPolicy, methodPolicy := testRetryPolicy(2), testRetryPolicy(3), testRetryPolicy(4)
+
+	configs.SetRetryPolicy(defaultPolicy).
+		SetServerRetryPolicy(testServer, serverPolicy).
+		SetMethodRetryPolicy(testMethod, methodPolicy)
+
+	assert.Same(t, methodPolicy, configs.getRetryPolicy(testServer, testMethod))
+	assert.Same(t, methodPolicy, configs.getRetryPolicy("other:8080", testMethod))
+	assert.Same(t, serverPolicy, configs.getRetryPolicy(testServer, "/test.Svc/List"))
+	assert.Same(t, defaultPolicy, configs.getRetryPolicy("other:8080", "/test.Svc/List"))
+}
+
+func TestGetRetryPolicyNil(t *testing.T) {
+	serverPolicy := testRetryPolicy(3)
+
+	configs := GetDefaultClientConfigs("test", true).
+		SetRetryPolicy(nil).
+		SetServerRetryPolicy(testServer, serverPolicy).
+		SetServerRetryPolicy("other:8080", nil).
+		SetMethodRetryPolicy(testMethod, nil)
+
+	assert.Same(t, serverPolicy, configs.getRetryPolicy(testServer, testMethod))
+	assert.Equal(t, GetDefaultRetryPolicy(), configs.getRetryPolicy("other:8080", testMethod))
+
+	configs.SetMaxAttempts(2)
+	assert.Equal(t, 2, configs.getRetryPolicy("other:8080", testMethod).MaxAttempts)
+}
+
+func TestStreamClientRetryInterceptor(t *testing.T) {
+	conn, err := grpc.Dial(testServer, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	var clientStream grpc.ClientStream
+
+	newStreamer := func(failures int, err error) (grpc.Streamer, *int) {
+		attempts := 0
+
+		return func(
+			ctx context.Context,
+			desc *grpc.StreamDesc,
+			cc *grpc.ClientConn,
+			method string,
+			opts ...grpc.CallOption,
+		) (grpc.ClientStream, error) {
+			attempts++
+			if attempts <= failures {
+				return nil, err
+			}
+
+			return clientStream, nil
+		}, &attempts
+	}
+
+	unavailableErr := status.Error(codes.Unavailable, "unavailable")
+	interceptor := StreamClientRetryInterceptor(testRetryPolicy(3))
+
+	streamer, attempts := newStreamer(2, unavailableErr)
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, conn, testMethod, streamer)
+	require.NoError(t, err)
+	assert.Equal(t, clientStream, stream)
+	assert.Equal(t, 3, *attempts)
+
+	streamer, attempts = newStreamer(3, unavailableErr)
+	_, err = interceptor(context.Background(), &grpc.StreamDesc{}, conn, testMethod, streamer)
+	assert.Equal(t, unavailableErr, err)
+	assert.Equal(t, 3, *attempts)
+
+	notFoundErr := status.Error(codes.NotFound, "not found")
+
+	streamer, attempts = newStreamer(3, notFoundErr)
+	_, err = interceptor(context.Background(), &grpc.StreamDesc{}, conn, testMethod, streamer)
+	assert.True(t, errors.Is(err, notFoundErr))
+	assert.Equal(t, 1, *attempts)
+
+	streamer, attempts = newStreamer(1, unavailableErr)
+	_, err = StreamClientRetryInterceptor(nil)(context.Background(), &grpc.StreamDesc{}, conn, testMethod, streamer)
+	assert.Equal(t, unavailableErr, err)
+	assert.Equal(t, 1, *attempts)
+}
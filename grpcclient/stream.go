@@ -0,0 +1,283 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	grpc_pool "github.com/processout/grpc-go-pool"
+	"github.com/twothicc/common-go/commonerror"
+	"github.com/twothicc/common-go/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Stream - client stream of a streaming method on a server.
+//
+// The connection of the stream is checked out of the pool of the server for the
+// stream's lifetime, and returned once the stream ends, errors or is closed.
+// Errors other than io.EOF are converted into common errors.
+//
+// Take special note that Close() must be called if the stream is abandoned
+// before it ends, e.g. with defer stream.Close().
+type Stream struct {
+	ctx     context.Context
+	stream  grpc.ClientStream
+	conn    *grpc_pool.ClientConn
+	cancel  context.CancelFunc
+	report  func(err error)
+	desc    *grpc.StreamDesc
+	address string
+	once    sync.Once
+}
+
+// NewStream - opens a stream of fullMethod on server, described by desc.
+//
+// Opening the stream is retried according to the retry policy of fullMethod on
+// server, and fails fast if the circuit breaker of server is open. If server is
+// the name of a logical service, the stream is opened to an endpoint of it.
+func (gc *Client) NewStream(
+	ctx context.Context,
+	server, fullMethod string,
+	desc *grpc.StreamDesc,
+) (*Stream, error) {
+	if gc == nil {
+		return nil, commonerror.New(commonerror.ErrCodeServer, "grpc client not initialized")
+	}
+
+	ctx = logger.EnsureRequestID(ctx)
+
+	address, report, commonErr := gc.allow(server)
+	if commonErr != nil {
+		return nil, commonErr
+	}
+
+	conn, commonErr := gc.getConn(ctx, address)
+	if commonErr != nil {
+		report(commonErr)
+
+		return nil, commonErr
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	stream, err := conn.NewStream(streamCtx, desc, fullMethod)
+	if err != nil {
+		cancel()
+		returnOrCloseConnection(ctx, address, conn)
+
+		commonErr = commonerror.Convert(err)
+		report(commonErr)
+
+		return nil, commonErr
+	}
+
+	return &Stream{
+		ctx:     ctx,
+		stream:  stream,
+		conn:    conn,
+		cancel:  cancel,
+		report:  report,
+		desc:    desc,
+		address: address,
+	}, nil
+}
+
+// SendMsg - sends m on the stream.
+//
+// io.EOF is returned if the stream was ended by the server, the error it ended
+// with is then returned by RecvMsg.
+func (s *Stream) SendMsg(m interface{}) error {
+	err := s.stream.SendMsg(m)
+	if err == nil || errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return s.finish(err)
+}
+
+// RecvMsg - receives a msg on the stream into m.
+//
+// io.EOF is returned once the stream ends successfully.
+func (s *Stream) RecvMsg(m interface{}) error {
+	err := s.stream.RecvMsg(m)
+	if err == nil {
+		if !s.desc.ServerStreams {
+			_ = s.finish(nil)
+		}
+
+		return nil
+	}
+
+	if errors.Is(err, io.EOF) {
+		_ = s.finish(nil)
+
+		return err
+	}
+
+	return s.finish(err)
+}
+
+// CloseSend - closes the sending direction of the stream.
+func (s *Stream) CloseSend() error {
+	if err := s.stream.CloseSend(); err != nil {
+		return s.finish(err)
+	}
+
+	return nil
+}
+
+// Header - returns the header metadata received from the server.
+func (s *Stream) Header() (metadata.MD, error) {
+	header, err := s.stream.Header()
+	if err != nil {
+		return nil, commonerror.Convert(err)
+	}
+
+	return header, nil
+}
+
+// Trailer - returns the trailer metadata received from the server, only
+// available once the stream has ended.
+func (s *Stream) Trailer() metadata.MD {
+	return s.stream.Trailer()
+}
+
+// Close - cancels the stream if it has not ended, and returns its connection to
+// the pool. Calling Close on an ended stream has no effect.
+func (s *Stream) Close() {
+	_ = s.finish(nil)
+}
+
+// finish - releases the stream once, reporting err to the circuit breaker of the
+// server, and returns err converted into a common error.
+func (s *Stream) finish(err error) error {
+	var commonErr commonerror.ICommonError
+	if err != nil {
+		commonErr = commonerror.Convert(err)
+	}
+
+	s.once.Do(func() {
+		s.cancel()
+		returnOrCloseConnection(s.ctx, s.address, s.conn)
+		s.report(commonErr)
+	})
+
+	if commonErr == nil {
+		return nil
+	}
+
+	return commonErr
+}
+
+// ServerStream - typed client stream of a server-streaming method.
+type ServerStream[Resp any] struct {
+	*Stream
+}
+
+// NewServerStream - opens a stream of the server-streaming fullMethod on server,
+// sending req.
+func NewServerStream[Req, Resp any](
+	ctx context.Context,
+	gc *Client,
+	server, fullMethod string,
+	req *Req,
+) (*ServerStream[Resp], error) {
+	stream, err := gc.NewStream(ctx, server, fullMethod, &grpc.StreamDesc{ServerStreams: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.SendMsg(req); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return &ServerStream[Resp]{Stream: stream}, nil
+}
+
+// Recv - receives the next response, returning io.EOF once the stream ends.
+func (s *ServerStream[Resp]) Recv() (*Resp, error) {
+	return recv[Resp](s.Stream)
+}
+
+// ClientStream - typed client stream of a client-streaming method.
+type ClientStream[Req, Resp any] struct {
+	*Stream
+}
+
+// NewClientStream - opens a stream of the client-streaming fullMethod on server.
+func NewClientStream[Req, Resp any](
+	ctx context.Context,
+	gc *Client,
+	server, fullMethod string,
+) (*ClientStream[Req, Resp], error) {
+	stream, err := gc.NewStream(ctx, server, fullMethod, &grpc.StreamDesc{ClientStreams: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientStream[Req, Resp]{Stream: stream}, nil
+}
+
+// Send - sends req on the stream.
+func (s *ClientStream[Req, Resp]) Send(req *Req) error {
+	return s.SendMsg(req)
+}
+
+// CloseAndRecv - closes the sending direction of the stream and receives the
+// response, ending the stream.
+func (s *ClientStream[Req, Resp]) CloseAndRecv() (*Resp, error) {
+	if err := s.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return recv[Resp](s.Stream)
+}
+
+// BidiStream - typed client stream of a bidirectional-streaming method.
+type BidiStream[Req, Resp any] struct {
+	*Stream
+}
+
+// NewBidiStream - opens a stream of the bidirectional-streaming fullMethod on server.
+func NewBidiStream[Req, Resp any](
+	ctx context.Context,
+	gc *Client,
+	server, fullMethod string,
+) (*BidiStream[Req, Resp], error) {
+	stream, err := gc.NewStream(ctx, server, fullMethod, &grpc.StreamDesc{
+		ServerStreams: true,
+		ClientStreams: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BidiStream[Req, Resp]{Stream: stream}, nil
+}
+
+// Send - sends req on the stream.
+func (s *BidiStream[Req, Resp]) Send(req *Req) error {
+	return s.SendMsg(req)
+}
+
+// Recv - receives the next response, returning io.EOF once the stream ends.
+func (s *BidiStream[Req, Resp]) Recv() (*Resp, error) {
+	return recv[Resp](s.Stream)
+}
+
+// recv - receives the next msg of type Resp on stream.
+func recv[Resp any](stream *Stream) (*Resp, error) {
+	resp := new(Resp)
+
+	if err := stream.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,142 @@
+package grpcclient
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// assertStreamReleased - asserts that the connection of the only endpoint of
+// testService was returned to its pool, and the end of the stream reported once.
+func assertStreamReleased(t *testing.T, client *Client) {
+	t.Helper()
+
+	endpoints := client.Pools.ServiceEndpoints(testService)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, int64(0), endpoints[0].Outstanding())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	conn, err := client.Pools.Get(ctx, endpoints[0].Address, false)
+	require.NoError(t, err, "connection of the stream not returned")
+	require.NoError(t, conn.Close())
+}
+
+func TestServerStreamEOF(t *testing.T) {
+	client := newTestClient(t, GetDefaultClientConfigs("test", true), startEchoServer(t, 0))
+
+	stream, err := NewServerStream[wrapperspb.StringValue, wrapperspb.StringValue](
+		context.Background(), client, testService, listMethod, wrapperspb.String("alice"),
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < testListResponses; i++ {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "alice", resp.GetValue())
+	}
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+	assertStreamReleased(t, client)
+
+	stream.Close()
+	assertStreamReleased(t, client)
+}
+
+func TestServerStreamError(t *testing.T) {
+	client := newTestClient(t, GetDefaultClientConfigs("test", true), startEchoServer(t, 0))
+
+	stream, err := NewServerStream[wrapperspb.StringValue, wrapperspb.StringValue](
+		context.Background(), client, testService, listMethod, wrapperspb.String(failValue),
+	)
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+	assert.Equal(t, int32(commonerror.ErrCodeUnavailable), commonerror.Convert(err).Code())
+	assertStreamReleased(t, client)
+
+	_, err = stream.Recv()
+	assert.Error(t, err)
+
+	stream.Close()
+	assertStreamReleased(t, client)
+}
+
+func TestStreamClose(t *testing.T) {
+	client := newTestClient(t, GetDefaultClientConfigs("test", true), startEchoServer(t, 0))
+
+	stream, err := NewBidiStream[wrapperspb.StringValue, wrapperspb.StringValue](
+		context.Background(), client, testService, chatMethod,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(wrapperspb.String("alice")))
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "alice", resp.GetValue())
+
+	stream.Close()
+	assertStreamReleased(t, client)
+
+	_, err = stream.Recv()
+	assert.Error(t, err)
+
+	stream.Close()
+	assertStreamReleased(t, client)
+}
+
+func TestBidiStreamEOF(t *testing.T) {
+	client := newTestClient(t, GetDefaultClientConfigs("test", true), startEchoServer(t, 0))
+
+	stream, err := NewBidiStream[wrapperspb.StringValue, wrapperspb.StringValue](
+		context.Background(), client, testService, chatMethod,
+	)
+	require.NoError(t, err)
+
+	for _, value := range []string{"alice", "bob"} {
+		require.NoError(t, stream.Send(wrapperspb.String(value)))
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, value, resp.GetValue())
+	}
+
+	require.NoError(t, stream.CloseSend())
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+	assertStreamReleased(t, client)
+}
+
+func TestClientStream(t *testing.T) {
+	client := newTestClient(t, GetDefaultClientConfigs("test", true), startEchoServer(t, 0))
+
+	stream, err := NewClientStream[wrapperspb.StringValue, wrapperspb.Int32Value](
+		context.Background(), client, testService, countMethod,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(wrapperspb.String("alice")))
+	require.NoError(t, stream.Send(wrapperspb.String("bob")))
+
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.GetValue())
+	assertStreamReleased(t, client)
+
+	stream.Close()
+	assertStreamReleased(t, client)
+}
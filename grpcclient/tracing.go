@@ -0,0 +1,31 @@
+package grpcclient
+
+import (
+	"context"
+
+	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+type clientSpanTagsKey struct{}
+
+// withClientSpanTags - adds tags to the client spans of calls made with ctx,
+// keeping the tags added before, as grpc_opentracing.ClientAddContextTags
+// replaces them.
+func withClientSpanTags(ctx context.Context, tags opentracing.Tags) context.Context {
+	merged := opentracing.Tags{}
+
+	if existing, ok := ctx.Value(clientSpanTagsKey{}).(opentracing.Tags); ok {
+		for key, value := range existing {
+			merged[key] = value
+		}
+	}
+
+	for key, value := range tags {
+		merged[key] = value
+	}
+
+	ctx = context.WithValue(ctx, clientSpanTagsKey{}, merged)
+
+	return grpc_opentracing.ClientAddContextTags(ctx, merged)
+}
@@ -16,3 +16,13 @@ const (
 const (
 	HTTP_READ_HEADER_TIMEOUT = 20 * time.Second
 )
+
+const (
+	ERROR_SEVERITY_TAG = "grpc.error.severity"
+)
+
+const (
+	// DEADLINE_BUDGET_METADATA_KEY - same as the key set by grpcclient.
+	DEADLINE_BUDGET_METADATA_KEY = "x-deadline-budget-ms"
+	DEADLINE_BUDGET_TAG          = "grpc.request.deadline_budget_ms"
+)
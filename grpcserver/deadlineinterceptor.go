@@ -0,0 +1,58 @@
+package grpcserver
+
+import (
+	"context"
+	"strconv"
+
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerDeadlineInterceptor - tags the deadline budget given by the caller,
+// propagated by grpcclient in incoming metadata, so that it is logged.
+func UnaryServerDeadlineInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		tagDeadlineBudget(ctx)
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerDeadlineInterceptor - tags the deadline budget given by the caller,
+// propagated by grpcclient in incoming metadata, so that it is logged.
+func StreamServerDeadlineInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		tagDeadlineBudget(stream.Context())
+
+		return handler(srv, stream)
+	}
+}
+
+// tagDeadlineBudget - tags the deadline budget in incoming metadata of ctx, in
+// milliseconds, if any.
+func tagDeadlineBudget(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	budgets := md.Get(DEADLINE_BUDGET_METADATA_KEY)
+	if len(budgets) == 0 {
+		return
+	}
+
+	budget, err := strconv.ParseInt(budgets[0], 10, 64)
+	if err != nil {
+		return
+	}
+
+	grpc_ctxtags.Extract(ctx).Set(DEADLINE_BUDGET_TAG, budget)
+}
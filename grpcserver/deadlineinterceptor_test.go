@@ -0,0 +1,67 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// contextServerStream - server stream of a ctx.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// incomingContext - returns a ctx with tags and the incoming metadata of pairs.
+func incomingContext(pairs ...string) (context.Context, grpc_ctxtags.Tags) {
+	tags := grpc_ctxtags.NewTags()
+	ctx := grpc_ctxtags.SetInContext(context.Background(), tags)
+
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(pairs...)), tags
+}
+
+func TestUnaryServerDeadlineInterceptor(t *testing.T) {
+	interceptor := UnaryServerDeadlineInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	ctx, tags := incomingContext(DEADLINE_BUDGET_METADATA_KEY, "1500")
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), tags.Values()[DEADLINE_BUDGET_TAG])
+
+	ctx, tags = incomingContext()
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.False(t, tags.Has(DEADLINE_BUDGET_TAG))
+
+	ctx, tags = incomingContext(DEADLINE_BUDGET_METADATA_KEY, "soon")
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.False(t, tags.Has(DEADLINE_BUDGET_TAG))
+}
+
+func TestStreamServerDeadlineInterceptor(t *testing.T) {
+	interceptor := StreamServerDeadlineInterceptor()
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+
+	ctx, tags := incomingContext(DEADLINE_BUDGET_METADATA_KEY, "1500")
+	require.NoError(t, interceptor(nil, &contextServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler))
+	assert.Equal(t, int64(1500), tags.Values()[DEADLINE_BUDGET_TAG])
+
+	ctx, tags = incomingContext()
+	require.NoError(t, interceptor(nil, &contextServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler))
+	assert.False(t, tags.Has(DEADLINE_BUDGET_TAG))
+}
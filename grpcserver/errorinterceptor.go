@@ -0,0 +1,95 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/twothicc/common-go/commonerror"
+	"github.com/twothicc/common-go/errortype"
+	"github.com/twothicc/common-go/logger"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// UnaryServerErrorInterceptor - converts errors returned by unary handlers into
+// grpc status errors.
+//
+// common errors keep their code, msg and details, errortype errors are converted
+// by errortype.ToCommonError. Other errors are returned as is.
+func UnaryServerErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+
+		return resp, toStatusError(ctx, err)
+	}
+}
+
+// StreamServerErrorInterceptor - converts errors returned by stream handlers into
+// grpc status errors.
+//
+// common errors keep their code, msg and details, errortype errors are converted
+// by errortype.ToCommonError. Other errors are returned as is.
+func StreamServerErrorInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return toStatusError(stream.Context(), handler(srv, stream))
+	}
+}
+
+// toStatusError - converts err into a grpc status error that commonerror.Convert
+// can decode back into the original common error.
+//
+// The localized user-facing msg for the locale of the incoming request, if
+// registered, is added to the status details. The errortype severity of err, if
+// specified, is tagged for the request completion log.
+func toStatusError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var commonError commonerror.ICommonError
+
+	var iError errortype.IError
+
+	if !errors.As(err, &commonError) && !errors.As(err, &iError) {
+		return err
+	}
+
+	if severity := errortype.SeverityOf(err); severity != errortype.SeverityUnspecified {
+		grpc_ctxtags.Extract(ctx).Set(ERROR_SEVERITY_TAG, severity)
+	}
+
+	commonError = errortype.ToCommonError(err)
+
+	return commonerror.Localize(ctx, commonError).GRPCStatus().Err()
+}
+
+// SeverityMessageProducer - produces the request completion log at the level
+// implied by the errortype severity of the error returned by the handler, if
+// tagged by the error interceptors, otherwise at the level of the grpc code.
+func SeverityMessageProducer(
+	ctx context.Context,
+	msg string,
+	level zapcore.Level,
+	code codes.Code,
+	err error,
+	duration zapcore.Field,
+) {
+	if severity, ok := grpc_ctxtags.Extract(ctx).Values()[ERROR_SEVERITY_TAG].(errortype.Severity); ok {
+		level = logger.SeverityLevel(severity)
+	}
+
+	grpc_zap.DefaultMessageProducer(ctx, msg, level, code, err, duration)
+}
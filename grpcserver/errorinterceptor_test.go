@@ -0,0 +1,260 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/commonerror"
+	"github.com/twothicc/common-go/errortype"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// errorService - handler type of the test service returning errors.
+type errorService interface{}
+
+// startErrorServer - starts a server with the error interceptors over bufconn,
+// whose methods return the error in errs of the requested name, returning a
+// connection to it.
+func startErrorServer(t *testing.T, errs map[string]error) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerErrorInterceptor()),
+		grpc.StreamInterceptor(StreamServerErrorInterceptor()),
+	)
+
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Errors",
+		HandlerType: (*errorService)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "Unary",
+				Handler: func(
+					srv interface{},
+					ctx context.Context,
+					dec func(interface{}) error,
+					interceptor grpc.UnaryServerInterceptor,
+				) (interface{}, error) {
+					req := &wrapperspb.StringValue{}
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+
+					handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+						return nil, errs[req.(*wrapperspb.StringValue).GetValue()]
+					}
+
+					return interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/test.Errors/Unary"}, handler)
+				},
+			},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "Stream",
+				ServerStreams: true,
+				Handler: func(srv interface{}, stream grpc.ServerStream) error {
+					req := &wrapperspb.StringValue{}
+					if err := stream.RecvMsg(req); err != nil {
+						return err
+					}
+
+					return errs[req.GetValue()]
+				},
+			},
+		},
+	}, nil)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	return conn
+}
+
+func TestServerErrorInterceptors(t *testing.T) {
+	details := &commonerror.Details{
+		Metadata:        map[string]string{"table": "users"},
+		FieldViolations: []*commonerror.FieldViolation{{Field: "name", Description: "must not be empty"}},
+	}
+	notFoundErrorType := errortype.ErrorType{Code: 1, Pkg: "grpcservertest", CommonCode: commonerror.ErrCodeNotFound}
+
+	tests := []struct {
+		err      error
+		details  *commonerror.Details
+		name     string
+		msg      string
+		code     int32
+		grpcCode codes.Code
+	}{
+		{
+			name:     "common error with details",
+			err:      commonerror.NewWithDetails(commonerror.ErrCodeServer, "failed to save user", details),
+			code:     commonerror.ErrCodeServer,
+			grpcCode: codes.Internal,
+			msg:      "failed to save user",
+			details:  details,
+		},
+		{
+			name:     "errortype error",
+			err:      notFoundErrorType.New("no row of id 1 in users"),
+			code:     commonerror.ErrCodeNotFound,
+			grpcCode: codes.NotFound,
+			msg:      commonerror.ErrMsgNotFound,
+		},
+		{
+			name:     "wrapped common error",
+			err:      fmt.Errorf("get user: %w", commonerror.New(commonerror.ErrCodeNotFound, "user not found")),
+			code:     commonerror.ErrCodeNotFound,
+			grpcCode: codes.NotFound,
+			msg:      "user not found",
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("connection reset"),
+			code:     commonerror.ErrCodeUnknown,
+			grpcCode: codes.Unknown,
+			msg:      "connection reset",
+		},
+	}
+
+	errs := make(map[string]error, len(tests))
+	for _, test := range tests {
+		errs[test.name] = test.err
+	}
+
+	conn := startErrorServer(t, errs)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := conn.Invoke(context.Background(), "/test.Errors/Unary", wrapperspb.String(test.name), &wrapperspb.StringValue{})
+			assertConvertedError(t, err, test.grpcCode, test.code, test.msg, test.details)
+
+			stream, err := conn.NewStream(context.Background(), &grpc.StreamDesc{ServerStreams: true}, "/test.Errors/Stream")
+			require.NoError(t, err)
+			require.NoError(t, stream.SendMsg(wrapperspb.String(test.name)))
+			require.NoError(t, stream.CloseSend())
+
+			err = stream.RecvMsg(&wrapperspb.StringValue{})
+			assertConvertedError(t, err, test.grpcCode, test.code, test.msg, test.details)
+		})
+	}
+}
+
+// assertConvertedError - asserts the grpc code of err, and the code, msg and
+// details of the common error converted from err.
+func assertConvertedError(
+	t *testing.T,
+	err error,
+	grpcCode codes.Code,
+	code int32,
+	msg string,
+	details *commonerror.Details,
+) {
+	t.Helper()
+
+	require.Error(t, err)
+	assert.Equal(t, grpcCode, status.Code(err))
+
+	commonError := commonerror.Convert(err)
+	assert.Equal(t, code, commonError.Code())
+	assert.Equal(t, msg, commonError.Msg())
+	assert.Equal(t, details, commonError.Details())
+}
+
+func TestSeverityMessageProducer(t *testing.T) {
+	tests := []struct {
+		severity interface{}
+		name     string
+		level    zapcore.Level
+		want     zapcore.Level
+	}{
+		{
+			name:  "untagged",
+			level: zapcore.ErrorLevel,
+			want:  zapcore.ErrorLevel,
+		},
+		{
+			name:     "info severity",
+			severity: errortype.SeverityInfo,
+			level:    zapcore.ErrorLevel,
+			want:     zapcore.InfoLevel,
+		},
+		{
+			name:     "warn severity",
+			severity: errortype.SeverityWarn,
+			level:    zapcore.ErrorLevel,
+			want:     zapcore.WarnLevel,
+		},
+		{
+			name:     "critical severity",
+			severity: errortype.SeverityCritical,
+			level:    zapcore.WarnLevel,
+			want:     zapcore.DPanicLevel,
+		},
+		{
+			name:     "tag of other type",
+			severity: "info",
+			level:    zapcore.WarnLevel,
+			want:     zapcore.WarnLevel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			tags := grpc_ctxtags.NewTags()
+			if test.severity != nil {
+				tags.Set(ERROR_SEVERITY_TAG, test.severity)
+			}
+
+			ctx := grpc_ctxtags.SetInContext(context.Background(), tags)
+			ctx = ctxzap.ToContext(ctx, zap.New(core))
+
+			SeverityMessageProducer(
+				ctx,
+				"finished unary call with code Internal",
+				test.level,
+				codes.Internal,
+				errors.New("failed"),
+				zap.Duration("grpc.time_ms", time.Millisecond),
+			)
+
+			require.Equal(t, 1, logs.Len())
+			assert.Equal(t, test.want, logs.All()[0].Level)
+			assert.Equal(t, codes.Internal.String(), logs.All()[0].ContextMap()["grpc.code"])
+		})
+	}
+}
@@ -176,8 +176,14 @@ func parseServerOptions(
 			grpc_ctxtags.WithFieldExtractor(BasicRequestFieldExtractor()),
 		),
 		grpc_opentracing.UnaryServerInterceptor(),
-		grpc_zap.UnaryServerInterceptor(logger.WithContext(ctx)),
+		UnaryServerDeadlineInterceptor(),
+		UnaryServerRequestIDInterceptor(),
+		grpc_zap.UnaryServerInterceptor(
+			logger.WithContext(ctx),
+			grpc_zap.WithMessageProducer(SeverityMessageProducer),
+		),
 		grpc_recovery.UnaryServerInterceptor(),
+		UnaryServerErrorInterceptor(),
 	}
 
 	streamInterceptors := []grpc.StreamServerInterceptor{
@@ -185,8 +191,14 @@ func parseServerOptions(
 			grpc_ctxtags.WithFieldExtractor(BasicRequestFieldExtractor()),
 		),
 		grpc_opentracing.StreamServerInterceptor(),
-		grpc_zap.StreamServerInterceptor(logger.WithContext(ctx)),
+		StreamServerDeadlineInterceptor(),
+		StreamServerRequestIDInterceptor(),
+		grpc_zap.StreamServerInterceptor(
+			logger.WithContext(ctx),
+			grpc_zap.WithMessageProducer(SeverityMessageProducer),
+		),
 		grpc_recovery.StreamServerInterceptor(),
+		StreamServerErrorInterceptor(),
 	}
 
 	if !configs.disableProm {
@@ -0,0 +1,62 @@
+package grpcserver
+
+import (
+	"context"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/twothicc/common-go/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerRequestIDInterceptor - sets the request id of incoming requests in
+// the handler's context, so that it is logged and propagated by grpcclient.
+//
+// The request id is taken from incoming metadata, or generated if there is none,
+// and returned to the caller in the header metadata.
+func UnaryServerRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		return handler(withRequestID(ctx), req)
+	}
+}
+
+// StreamServerRequestIDInterceptor - sets the request id of incoming streams in
+// the handler's context, so that it is logged and propagated by grpcclient.
+//
+// The request id is taken from incoming metadata, or generated if there is none,
+// and returned to the caller in the header metadata.
+func StreamServerRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		wrappedStream := grpc_middleware.WrapServerStream(stream)
+		wrappedStream.WrappedContext = withRequestID(stream.Context())
+
+		return handler(srv, wrappedStream)
+	}
+}
+
+// withRequestID - returns ctx carrying the request id of the incoming request,
+// tagging it for the request completion log.
+func withRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := logger.NewRequestID()
+	if requestIDs := md.Get(logger.REQUEST_ID_METADATA_KEY); len(requestIDs) > 0 && requestIDs[0] != "" {
+		requestID = requestIDs[0]
+	}
+
+	grpc_ctxtags.Extract(ctx).Set(logger.REQUEST_ID_FIELD, requestID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(logger.REQUEST_ID_METADATA_KEY, requestID))
+
+	return logger.WithRequestID(ctx, requestID)
+}
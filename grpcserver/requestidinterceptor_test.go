@@ -0,0 +1,62 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/logger"
+	"google.golang.org/grpc"
+)
+
+func TestUnaryServerRequestIDInterceptor(t *testing.T) {
+	interceptor := UnaryServerRequestIDInterceptor()
+
+	var requestID string
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestID = logger.RequestID(ctx)
+
+		return req, nil
+	}
+
+	ctx, tags := incomingContext(logger.REQUEST_ID_METADATA_KEY, "abc")
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", requestID)
+	assert.Equal(t, "abc", tags.Values()[logger.REQUEST_ID_FIELD])
+
+	ctx, tags = incomingContext()
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Len(t, requestID, 2*logger.REQUEST_ID_BYTES)
+	assert.Equal(t, requestID, tags.Values()[logger.REQUEST_ID_FIELD])
+
+	ctx, _ = incomingContext(logger.REQUEST_ID_METADATA_KEY, "")
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Len(t, requestID, 2*logger.REQUEST_ID_BYTES)
+}
+
+func TestStreamServerRequestIDInterceptor(t *testing.T) {
+	interceptor := StreamServerRequestIDInterceptor()
+
+	var requestID string
+
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		requestID = logger.RequestID(stream.Context())
+
+		return nil
+	}
+
+	ctx, tags := incomingContext(logger.REQUEST_ID_METADATA_KEY, "abc")
+	require.NoError(t, interceptor(nil, &contextServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler))
+	assert.Equal(t, "abc", requestID)
+	assert.Equal(t, "abc", tags.Values()[logger.REQUEST_ID_FIELD])
+
+	ctx, tags = incomingContext()
+	require.NoError(t, interceptor(nil, &contextServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler))
+	assert.Len(t, requestID, 2*logger.REQUEST_ID_BYTES)
+	assert.Equal(t, requestID, tags.Values()[logger.REQUEST_ID_FIELD])
+}
@@ -5,6 +5,20 @@ const (
 	CONSOLE_SEPARATOR = "|"
 )
 
+// field constants
+const (
+	STACK_FIELD_SUFFIX = "Stack"
+	ERROR_FIELD        = "error"
+	SEVERITY_FIELD     = "severity"
+	REQUEST_ID_FIELD   = "request.id"
+)
+
+// request id constants
+const (
+	REQUEST_ID_METADATA_KEY = "x-request-id"
+	REQUEST_ID_BYTES        = 16
+)
+
 // log file constants
 const (
 	LOG_FILENAME   = "server.log"
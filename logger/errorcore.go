@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/twothicc/common-go/errortype"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// stackTracer - errors carrying the stack captured at their creation,
+// e.g. errortype.Error.
+type stackTracer interface {
+	StackTrace() []runtime.Frame
+}
+
+// errorCore - zapcore.Core that logs the stack trace and the fields attached to
+// errortype errors as structured fields next to the error.
+type errorCore struct {
+	zapcore.Core
+}
+
+// newErrorCore - wraps core to log stack traces and fields of errors carrying them.
+func newErrorCore(core zapcore.Core) zapcore.Core {
+	return &errorCore{Core: core}
+}
+
+func (c *errorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorCore{Core: c.Core.With(expandErrorFields(fields))}
+}
+
+func (c *errorCore) Check(entry zapcore.Entry, checkedEntry *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checkedEntry.AddCore(entry, c)
+	}
+
+	return checkedEntry
+}
+
+func (c *errorCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, expandErrorFields(fields))
+}
+
+// expandErrorFields - expands error fields whose error carries a stack trace or
+// errortype fields.
+//
+// The error field is replaced with the error msg, followed by a <key>Stack field
+// listing the stack frames and one field per errortype field.
+func expandErrorFields(fields []zapcore.Field) []zapcore.Field {
+	var expandedFields []zapcore.Field
+
+	for i, field := range fields {
+		var extraFields []zapcore.Field
+
+		err, ok := field.Interface.(error)
+		if field.Type == zapcore.ErrorType && ok {
+			extraFields = errorFields(field.Key, err)
+		}
+
+		if extraFields == nil {
+			if expandedFields != nil {
+				expandedFields = append(expandedFields, field)
+			}
+
+			continue
+		}
+
+		if expandedFields == nil {
+			expandedFields = make([]zapcore.Field, i, len(fields)+len(extraFields))
+			copy(expandedFields, fields[:i])
+		}
+
+		expandedFields = append(expandedFields, zap.String(field.Key, err.Error()))
+		expandedFields = append(expandedFields, extraFields...)
+	}
+
+	if expandedFields == nil {
+		return fields
+	}
+
+	return expandedFields
+}
+
+// errorFields - returns the stack trace and errortype fields of err logged
+// under key, nil if there are none.
+func errorFields(key string, err error) []zapcore.Field {
+	var extraFields []zapcore.Field
+
+	var tracer stackTracer
+	if errors.As(err, &tracer) {
+		if frames := tracer.StackTrace(); len(frames) > 0 {
+			stackTrace := make([]string, 0, len(frames))
+			for _, frame := range frames {
+				stackTrace = append(stackTrace, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+			}
+
+			extraFields = append(extraFields, zap.Strings(key+STACK_FIELD_SUFFIX, stackTrace))
+		}
+	}
+
+	var iError errortype.IError
+	if errors.As(err, &iError) {
+		for _, errorField := range iError.Fields() {
+			extraFields = append(extraFields, zap.Any(errorField.Key, errorField.Value))
+		}
+	}
+
+	return extraFields
+}
+
+// ErrorFields - returns the fields attached to err as zap fields, nil if err
+// is not an errortype error.
+//
+// Useful for loggers not initialized by InitLogger.
+func ErrorFields(err error) []zapcore.Field {
+	var iError errortype.IError
+	if !errors.As(err, &iError) {
+		return nil
+	}
+
+	errorFields := iError.Fields()
+	zapFields := make([]zapcore.Field, 0, len(errorFields))
+
+	for _, errorField := range errorFields {
+		zapFields = append(zapFields, zap.Any(errorField.Key, errorField.Value))
+	}
+
+	return zapFields
+}
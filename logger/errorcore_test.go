@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/errortype"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// stackError - error carrying a stack trace, as errortype errors do.
+type stackError struct {
+	frames []runtime.Frame
+}
+
+func (e *stackError) Error() string {
+	return "failed"
+}
+
+func (e *stackError) StackTrace() []runtime.Frame {
+	return e.frames
+}
+
+var dummyErrorType = errortype.ErrorType{Code: 1, Pkg: "loggertest"}
+
+var dummyStackError = &stackError{
+	frames: []runtime.Frame{
+		{Function: "github.com/twothicc/common-go/loggertest.Get", File: "/loggertest/get.go", Line: 12},
+		{Function: "main.main", File: "/loggertest/main.go", Line: 5},
+	},
+}
+
+func TestErrorStackField(t *testing.T) {
+	logs := observeLogs(t, zapcore.DebugLevel)
+
+	err := fmt.Errorf("handler: %w", dummyStackError)
+	WithContext(context.Background()).Error("request failed", zap.Error(err))
+
+	require.Equal(t, 1, logs.Len())
+
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, err.Error(), fields["error"])
+	assert.Equal(t, []interface{}{
+		"github.com/twothicc/common-go/loggertest.Get /loggertest/get.go:12",
+		"main.main /loggertest/main.go:5",
+	}, fields["error"+STACK_FIELD_SUFFIX])
+}
+
+func TestErrorStackFieldWith(t *testing.T) {
+	logs := observeLogs(t, zapcore.DebugLevel)
+
+	WithContext(context.Background()).With(zap.NamedError("cause", dummyStackError)).Info("retrying")
+
+	require.Equal(t, 1, logs.Len())
+
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, dummyStackError.Error(), fields["cause"])
+	assert.Contains(t, fields, "cause"+STACK_FIELD_SUFFIX)
+}
+
+func TestErrorWithoutStackUnchanged(t *testing.T) {
+	logs := observeLogs(t, zapcore.DebugLevel)
+
+	WithContext(context.Background()).Warn("request failed",
+		zap.Error(errors.New("plain")),
+		zap.Error(&stackError{}),
+	)
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, []zapcore.Field{zap.Error(errors.New("plain")), zap.Error(&stackError{})}, logs.All()[0].Context)
+}
+
+func TestNonErrorErrorFieldUnchanged(t *testing.T) {
+	fields := []zapcore.Field{{Key: "error", Type: zapcore.ErrorType, Interface: "failed"}}
+
+	assert.Equal(t, fields, expandErrorFields(fields))
+}
+
+func TestErrorTypeFields(t *testing.T) {
+	logs := observeLogs(t, zapcore.DebugLevel)
+
+	err := dummyErrorType.New("failed",
+		errortype.String("user", "alice"),
+		errortype.Int("attempt", 3),
+		errortype.Duration("elapsed", time.Second),
+	)
+	WithContext(context.Background()).Error("request failed", zap.Error(fmt.Errorf("handler: %w", err)))
+
+	require.Equal(t, 1, logs.Len())
+
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "alice", fields["user"])
+	assert.EqualValues(t, 3, fields["attempt"])
+	assert.Equal(t, time.Second, fields["elapsed"])
+	assert.NotContains(t, fields, "fields")
+	assert.Contains(t, fields, "error"+STACK_FIELD_SUFFIX)
+}
+
+func TestErrorFields(t *testing.T) {
+	assert.Nil(t, ErrorFields(errors.New("plain")))
+
+	err := dummyErrorType.New("failed", errortype.String("user", "alice"), errortype.Bool("retried", true))
+	assert.Equal(t, []zapcore.Field{zap.Any("user", "alice"), zap.Any("retried", true)}, ErrorFields(err))
+}
@@ -1,17 +1,32 @@
 module github.com/twothicc/common-go/logger
 
-go 1.18
+go 1.20
 
-require go.uber.org/zap v1.21.0
+require (
+	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
+	github.com/stretchr/testify v1.8.0
+	github.com/twothicc/common-go/errortype v0.0.0-00010101000000-000000000000
+	go.uber.org/zap v1.21.0
+)
 
 require (
-	github.com/golang/protobuf v1.3.3 // indirect
-	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.4.0 // indirect
+	github.com/twothicc/common-go/commonerror v0.0.0-20220815084053-2bc49f4b1954 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
 	golang.org/x/net v0.0.0-20210405180319-a5a99cb37ef4 // indirect
 	golang.org/x/sys v0.0.0-20210510120138-977fb7262007 // indirect
 	golang.org/x/text v0.3.3 // indirect
-	google.golang.org/genproto v0.0.0-20200423170343-7949de9c1215 // indirect
-	google.golang.org/grpc v1.29.1 // indirect
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013 // indirect
+	google.golang.org/grpc v1.48.0 // indirect
+	google.golang.org/protobuf v1.27.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/twothicc/common-go/commonerror => ../commonerror
+	github.com/twothicc/common-go/errortype => ../errortype
 )
@@ -44,16 +44,17 @@ func InitLogger(level zapcore.Level) {
 
 	defaultLogLevel := level
 
-	core := zapcore.NewTee(
+	core := newErrorCore(zapcore.NewTee(
 		zapcore.NewCore(fileEncoder, writer, defaultLogLevel),
-	)
+	))
 	cLogger.logger = zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
 }
 
 // WithContext - returns the context logger, or the default logger if context
 // logger does not exist.
 //
-// Default log fields are determined by the entries of defaultLogFields.
+// Default log fields are determined by the entries of defaultLogFields, followed
+// by the request id carried by ctx, if any.
 func WithContext(ctx context.Context) *zap.Logger {
 	if ctx == nil {
 		return cLogger.logger
@@ -77,6 +78,10 @@ func WithContext(ctx context.Context) *zap.Logger {
 		}
 	}
 
+	if requestID := RequestID(ctx); requestID != "" {
+		zapFields = append(zapFields, zap.String(REQUEST_ID_FIELD, requestID))
+	}
+
 	zapFields = append(zapFields, cLogger.fields...)
 
 	return currLogger.logger.With(zapFields...)
@@ -0,0 +1,26 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// observeLogs - replaces the default logger with one set up as by InitLogger,
+// writing to an observer at level, for the duration of the test.
+func observeLogs(t *testing.T, level zapcore.Level) *observer.ObservedLogs {
+	t.Helper()
+
+	core, logs := observer.New(level)
+	defaultLogger := cLogger.logger
+
+	cLogger.logger = zap.New(newErrorCore(core), zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+
+	t.Cleanup(func() {
+		cLogger.logger = defaultLogger
+	})
+
+	return logs
+}
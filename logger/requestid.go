@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// requestIDMarker - for extracting request id from ctx
+type requestIDMarker struct{}
+
+var requestIDMarkerKey = &requestIDMarker{}
+
+// NewRequestID - generates a random request id.
+func NewRequestID() string {
+	requestID := make([]byte, REQUEST_ID_BYTES)
+	if _, err := rand.Read(requestID); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(requestID)
+}
+
+// WithRequestID - returns ctx carrying requestID, which is logged by the context
+// logger and propagated by grpcclient.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDMarkerKey, requestID)
+}
+
+// RequestID - returns the request id carried by ctx, empty if there is none.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestID, _ := ctx.Value(requestIDMarkerKey).(string)
+
+	return requestID
+}
+
+// EnsureRequestID - returns ctx carrying a request id, generating one if ctx
+// does not carry any.
+func EnsureRequestID(ctx context.Context) context.Context {
+	if RequestID(ctx) != "" {
+		return ctx
+	}
+
+	return WithRequestID(ctx, NewRequestID())
+}
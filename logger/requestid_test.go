@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewRequestID(t *testing.T) {
+	requestID := NewRequestID()
+
+	decoded, err := hex.DecodeString(requestID)
+	require.NoError(t, err)
+	assert.Len(t, decoded, REQUEST_ID_BYTES)
+	assert.NotEqual(t, requestID, NewRequestID())
+}
+
+func TestRequestID(t *testing.T) {
+	assert.Empty(t, RequestID(context.Background()))
+	assert.Empty(t, RequestID(nil)) //nolint:staticcheck // nil ctx is handled
+
+	ctx := WithRequestID(context.Background(), "abc")
+	assert.Equal(t, "abc", RequestID(ctx))
+}
+
+func TestEnsureRequestID(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "abc")
+	assert.Equal(t, ctx, EnsureRequestID(ctx))
+
+	generatedCtx := EnsureRequestID(context.Background())
+	assert.Len(t, RequestID(generatedCtx), 2*REQUEST_ID_BYTES)
+}
+
+func TestWithContextRequestID(t *testing.T) {
+	logs := observeLogs(t, zapcore.DebugLevel)
+
+	WithContext(context.Background()).Info("no request id")
+	WithContext(WithRequestID(context.Background(), "abc")).Info("request id")
+
+	require.Equal(t, 2, logs.Len())
+	assert.NotContains(t, logs.All()[0].ContextMap(), REQUEST_ID_FIELD)
+	assert.Equal(t, "abc", logs.All()[1].ContextMap()[REQUEST_ID_FIELD])
+}
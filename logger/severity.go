@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/twothicc/common-go/errortype"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// severityLevels - levels errors are logged at by their errortype severity.
+//
+// Critical errors are logged at dpanic level, so that they rank above other
+// errors. Loggers built by InitLogger are not in development mode, and so do
+// not panic at dpanic level.
+var severityLevels = map[errortype.Severity]zapcore.Level{
+	errortype.SeverityUnspecified: zapcore.ErrorLevel,
+	errortype.SeverityInfo:        zapcore.InfoLevel,
+	errortype.SeverityWarn:        zapcore.WarnLevel,
+	errortype.SeverityError:       zapcore.ErrorLevel,
+	errortype.SeverityCritical:    zapcore.DPanicLevel,
+}
+
+// SeverityLevel - returns the level implied by severity.
+func SeverityLevel(severity errortype.Severity) zapcore.Level {
+	if level, ok := severityLevels[severity]; ok {
+		return level
+	}
+
+	return zapcore.ErrorLevel
+}
+
+// ErrorLevel - returns the level implied by the errortype severity of err,
+// error level if err has no severity.
+func ErrorLevel(err error) zapcore.Level {
+	return SeverityLevel(errortype.SeverityOf(err))
+}
+
+// LogError - logs err with msg using the context logger, at the level implied by
+// the errortype severity of err.
+//
+// The severity is logged as a field. Stack traces of errors logged below error
+// level are omitted, the fields attached to them are still logged.
+func LogError(ctx context.Context, msg string, err error, fields ...zapcore.Field) {
+	severity := errortype.SeverityOf(err)
+	level := SeverityLevel(severity)
+
+	checkedEntry := WithContext(ctx).WithOptions(zap.AddCallerSkip(1)).Check(level, msg)
+	if checkedEntry == nil {
+		return
+	}
+
+	if severity == errortype.SeverityUnspecified {
+		severity = errortype.SeverityError
+	}
+
+	fields = append(fields, zap.Stringer(SEVERITY_FIELD, severity))
+
+	if level >= zapcore.ErrorLevel || err == nil {
+		checkedEntry.Write(append(fields, zap.Error(err))...)
+
+		return
+	}
+
+	fields = append(fields, zap.String(ERROR_FIELD, err.Error()))
+	checkedEntry.Write(append(fields, ErrorFields(err)...)...)
+}
@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twothicc/common-go/errortype"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSeverityLevel(t *testing.T) {
+	assert.Equal(t, zapcore.ErrorLevel, SeverityLevel(errortype.SeverityUnspecified))
+	assert.Equal(t, zapcore.InfoLevel, SeverityLevel(errortype.SeverityInfo))
+	assert.Equal(t, zapcore.WarnLevel, SeverityLevel(errortype.SeverityWarn))
+	assert.Equal(t, zapcore.ErrorLevel, SeverityLevel(errortype.SeverityError))
+	assert.Equal(t, zapcore.DPanicLevel, SeverityLevel(errortype.SeverityCritical))
+	assert.Equal(t, zapcore.ErrorLevel, SeverityLevel(errortype.Severity(100)))
+
+	assert.Equal(t, zapcore.ErrorLevel, ErrorLevel(errors.New("plain")))
+}
+
+func TestLogError(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		severity string
+		level    zapcore.Level
+	}{
+		{
+			name:     "plain error",
+			err:      errors.New("plain"),
+			level:    zapcore.ErrorLevel,
+			severity: errortype.SeverityError.String(),
+		},
+		{
+			name:     "unspecified severity",
+			err:      dummyErrorType.New("failed"),
+			level:    zapcore.ErrorLevel,
+			severity: errortype.SeverityError.String(),
+		},
+		{
+			name:     "info severity",
+			err:      errortype.ErrorType{Code: 2, Pkg: "loggertest", Severity: errortype.SeverityInfo}.New("failed"),
+			level:    zapcore.InfoLevel,
+			severity: errortype.SeverityInfo.String(),
+		},
+		{
+			name:     "warn severity",
+			err:      errortype.ErrorType{Code: 3, Pkg: "loggertest", Severity: errortype.SeverityWarn}.New("failed"),
+			level:    zapcore.WarnLevel,
+			severity: errortype.SeverityWarn.String(),
+		},
+		{
+			name:     "critical severity",
+			err:      errortype.ErrorType{Code: 4, Pkg: "loggertest", Severity: errortype.SeverityCritical}.New("failed"),
+			level:    zapcore.DPanicLevel,
+			severity: errortype.SeverityCritical.String(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logs := observeLogs(t, zapcore.DebugLevel)
+
+			LogError(context.Background(), "request failed", test.err, zap.String("method", "get"))
+
+			require.Equal(t, 1, logs.Len())
+
+			entry := logs.All()[0]
+			assert.Equal(t, test.level, entry.Level)
+			assert.Equal(t, "request failed", entry.Message)
+			assert.True(t, entry.Caller.Defined)
+			assert.Contains(t, entry.Caller.File, "severity_test.go")
+
+			fields := entry.ContextMap()
+			assert.Equal(t, "get", fields["method"])
+			assert.Equal(t, test.severity, fields[SEVERITY_FIELD])
+			assert.Equal(t, test.err.Error(), fields[ERROR_FIELD])
+		})
+	}
+}
+
+func TestLogErrorStackBelowErrorLevel(t *testing.T) {
+	logs := observeLogs(t, zapcore.DebugLevel)
+
+	warnErrorType := errortype.ErrorType{Code: 5, Pkg: "loggertest", Severity: errortype.SeverityWarn}
+	LogError(context.Background(), "request failed", warnErrorType.New("failed", errortype.String("user", "alice")))
+	LogError(context.Background(), "request failed", dummyErrorType.New("failed", errortype.String("user", "alice")))
+
+	require.Equal(t, 2, logs.Len())
+
+	warnFields := logs.All()[0].ContextMap()
+	assert.NotContains(t, warnFields, ERROR_FIELD+STACK_FIELD_SUFFIX)
+	assert.Equal(t, "alice", warnFields["user"])
+	assert.Empty(t, logs.All()[0].Stack)
+
+	errorFields := logs.All()[1].ContextMap()
+	assert.Contains(t, errorFields, ERROR_FIELD+STACK_FIELD_SUFFIX)
+	assert.Equal(t, "alice", errorFields["user"])
+}
+
+func TestLogErrorDisabledLevel(t *testing.T) {
+	logs := observeLogs(t, zapcore.WarnLevel)
+
+	infoErrorType := errortype.ErrorType{Code: 6, Pkg: "loggertest", Severity: errortype.SeverityInfo}
+	LogError(context.Background(), "request failed", infoErrorType.New("failed"))
+
+	assert.Equal(t, 0, logs.Len())
+}